@@ -0,0 +1,43 @@
+// cmd/mcp-server/embedded.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/admin"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/collector"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// embeddedLeaseTTL bounds the in-process lease; nothing else competes for it
+const embeddedLeaseTTL = 15 * time.Second
+
+// startEmbeddedCollector runs the collector inside this process, writing
+// straight into store. mesh is the in-memory backend carrying the policy
+// deltas this server publishes to the collector for reconciliation. The
+// collector adds its readiness checks to checks.
+func startEmbeddedCollector(ctx context.Context, cfg ServerConfig, store *graph.Store, mesh backend.Backend, clientset kubernetes.Interface, dynClient dynamic.Interface, checks *admin.Checks) {
+	host, _ := os.Hostname()
+	collectorCfg := collector.Config{
+		PrometheusURL: cfg.PrometheusURL,
+		Origin:        fmt.Sprintf("%s%s:%d", collector.OriginPrefix, host, os.Getpid()),
+		Checks:        checks,
+	}
+	go func() {
+		err := collector.Run(ctx, collectorCfg, store, mesh, clientset, dynClient, func(callbacks leader.Callbacks) (leader.Elector, error) {
+			return mesh.NewElector(collectorCfg.Origin, embeddedLeaseTTL, callbacks), nil
+		})
+		if err != nil {
+			fmt.Printf("Embedded collector failed: %v\n", err)
+		}
+	}()
+}
@@ -5,31 +5,206 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 
+	"github.com/eli-nomasec/linkerd2-mcp/internal/admin"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
 	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
 	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
-	redisutil "github.com/eli-nomasec/linkerd2-mcp/internal/redis"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/kube"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/mcp"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
 )
 
+const version = "0.3.0"
+
+type ServerConfig struct {
+	// BackendURL addresses the graph store backend (see backend.Open)
+	BackendURL string
+	// MCPHTTPAddr is the listen address for the MCP streamable HTTP transport ("" disables it)
+	MCPHTTPAddr string
+	// MCPAllowedOrigins are browser origins, besides localhost, allowed to
+	// use the MCP HTTP transport
+	MCPAllowedOrigins []string
+	// MCPStdio serves MCP over stdin/stdout; logs are redirected to stderr
+	MCPStdio bool
+	// Embedded runs the collector in this process on an in-memory backend,
+	// so no Redis or separate collector is needed
+	Embedded bool
+	// PrometheusURL is where the embedded collector reads metrics
+	PrometheusURL string
+	// StaleAfter is how long a data source may go unconfirmed before the
+	// graph fields it feeds are reported stale
+	StaleAfter time.Duration
+	// AdminAddr is the listen address for /healthz, /ready and /metrics ("" disables it)
+	AdminAddr string
+	// DryRun validates requested policies with a server-side dry-run in
+	// addition to the offline schema check
+	DryRun bool
+}
+
+func getConfigFromEnv() ServerConfig {
+	httpAddr, ok := os.LookupEnv("MCP_SERVER_MCP_HTTP_ADDR")
+	if !ok {
+		// The tools mutate the cluster unauthenticated, so stay local by default
+		httpAddr = "127.0.0.1:10901"
+	}
+	var allowedOrigins []string
+	for _, origin := range strings.Split(os.Getenv("MCP_SERVER_MCP_ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins = append(allowedOrigins, origin)
+		}
+	}
+	backendURL := os.Getenv("MCP_SERVER_BACKEND_URL")
+	if backendURL == "" {
+		backendURL = os.Getenv("MCP_SERVER_REDIS_URL")
+	}
+	if backendURL == "" {
+		backendURL = "localhost:6379"
+	}
+	staleAfter, err := time.ParseDuration(os.Getenv("MCP_SERVER_STALE_AFTER"))
+	if err != nil || staleAfter <= 0 {
+		staleAfter = time.Minute
+	}
+	adminAddr, ok := os.LookupEnv("MCP_SERVER_ADMIN_ADDR")
+	if !ok {
+		adminAddr = ":9990"
+	}
+	promURL := os.Getenv("MCP_SERVER_PROMETHEUS_URL")
+	if promURL == "" {
+		promURL = "http://localhost:9090"
+	}
+	return ServerConfig{
+		BackendURL:        backendURL,
+		MCPHTTPAddr:       httpAddr,
+		MCPAllowedOrigins: allowedOrigins,
+		MCPStdio:          os.Getenv("MCP_SERVER_MCP_STDIO") == "true",
+		Embedded:          os.Getenv("MCP_SERVER_EMBEDDED") == "true",
+		PrometheusURL:     promURL,
+		StaleAfter:        staleAfter,
+		AdminAddr:         adminAddr,
+		DryRun:            os.Getenv("MCP_SERVER_DRY_RUN") != "false",
+	}
+}
+
 type server struct {
 	pb.UnimplementedMeshContextServer
-	mesh *graph.MeshGraph
+	store *graph.Store
+	hub   *watchHub
+	mesh  backend.Backend
+	// origin is stamped on the deltas this server publishes
+	origin string
+	// staleAfter is the age at which a source's data is reported stale
+	staleAfter time.Duration
+	// validator checks requested policies before they are applied
+	validator *policy.Validator
+	// kube applies policies directly; when nil they are published for the
+	// collector to apply
+	kube dynamic.Interface
 }
 
 func (s *server) GetMeshGraph(ctx context.Context, req *pb.GetMeshGraphRequest) (*pb.GetMeshGraphResponse, error) {
-	data, err := json.Marshal(s.mesh)
+	mesh, rev := s.store.VersionedSnapshot()
+	status := graph.FreshnessToProto(mesh.Freshness(time.Now(), s.staleAfter))
+	if req.GetIfNewerThan() > 0 && rev.Seq <= req.GetIfNewerThan() {
+		return &pb.GetMeshGraphResponse{Revision: graph.RevisionToProto(rev), NotModified: true, Status: status}, nil
+	}
+	g, err := graph.ToProto(mesh)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert mesh graph: %w", err)
+	}
+	resp := &pb.GetMeshGraphResponse{Graph: g, Revision: graph.RevisionToProto(rev), Status: status}
+	if req.GetIncludeJson() {
+		data, err := json.Marshal(mesh)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal mesh graph: %w", err)
+		}
+		resp.JsonGraph = string(data)
+	}
+	return resp, nil
+}
+
+// GetStatus reports how current the graph is, per data source
+func (s *server) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.GetStatusResponse, error) {
+	var freshness graph.Freshness
+	s.store.View(func(mesh *graph.MeshGraph) {
+		freshness = mesh.Freshness(time.Now(), s.staleAfter)
+	})
+	return &pb.GetStatusResponse{
+		Status:   graph.FreshnessToProto(freshness),
+		Revision: graph.RevisionToProto(s.store.Revision()),
+	}, nil
+}
+
+// GetCallGraph returns the call edges matching the request filters
+func (s *server) GetCallGraph(ctx context.Context, req *pb.GetCallGraphRequest) (*pb.GetCallGraphResponse, error) {
+	resp := &pb.GetCallGraphResponse{}
+	filter := graph.EdgeFilter{
+		Namespace:  req.GetNamespace(),
+		Source:     req.GetSource(),
+		NonTLSOnly: req.GetNonTlsOnly(),
+	}
+	s.store.View(func(mesh *graph.MeshGraph) {
+		for _, e := range mesh.FilterEdges(filter) {
+			resp.Edges = append(resp.Edges, graph.EdgeToProto(e))
+		}
+	})
+	return resp, nil
+}
+
+// WatchMeshGraph streams a snapshot (or the missed events on resume) and then
+// every change the server applies to its graph
+func (s *server) WatchMeshGraph(req *pb.WatchMeshGraphRequest, stream pb.MeshContext_WatchMeshGraphServer) error {
+	w, backlog, err := s.hub.subscribe(req.GetResumeFromVersion(), req.GetResumeEpoch())
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal mesh graph: %w", err)
+		return status.Errorf(codes.Internal, "failed to snapshot mesh graph: %v", err)
+	}
+	defer s.hub.unsubscribe(w)
+
+	var last uint64
+	for _, event := range backlog {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+		last = event.Version
+	}
+	for {
+		select {
+		case event, ok := <-w.events:
+			if !ok {
+				if s.hub.isDropped(w) {
+					return status.Errorf(codes.ResourceExhausted, "watcher fell behind; resume from version %d of epoch %s", last, s.hub.epoch)
+				}
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			last = event.Version
+		case <-stream.Context().Done():
+			return nil
+		}
 	}
-	return &pb.GetMeshGraphResponse{JsonGraph: string(data)}, nil
 }
 
-// ApplyAuthorizationPolicy: mutate mesh graph and publish delta to Redis
+// ApplyAuthorizationPolicy validates the policy and applies it to the cluster,
+// or, without cluster access, publishes it for the collector to apply
 func (s *server) ApplyAuthorizationPolicy(ctx context.Context, req *pb.ApplyAuthorizationPolicyRequest) (*pb.ApplyAuthorizationPolicyResponse, error) {
 	fmt.Printf("Received ApplyAuthorizationPolicy: ns=%s name=%s\n", req.Namespace, req.Name)
 
@@ -39,92 +214,339 @@ func (s *server) ApplyAuthorizationPolicy(ctx context.Context, req *pb.ApplyAuth
 		return &pb.ApplyAuthorizationPolicyResponse{
 			Accepted: false,
 			Message:  fmt.Sprintf("Invalid JSON spec: %v", err),
+			Errors:   []*pb.FieldError{{Field: "spec", Type: string(metav1.CauseTypeFieldValueInvalid), Message: err.Error()}},
 		}, nil
 	}
 
-	// Update mesh graph in memory
-	policyKey := req.Namespace + "/" + req.Name
-	s.mesh.AuthPolicies[policyKey] = graph.AuthPolicy{
-		Name: req.Name,
-		Spec: spec,
-	}
-
-	// Serialize delta (currently publishes full mesh graph)
-	delta, err := json.Marshal(s.mesh)
+	// Reject invalid policies now rather than when the collector applies them
+	fieldErrs, err := s.validator.AuthorizationPolicy(ctx, req.Namespace, req.Name, spec)
 	if err != nil {
 		return &pb.ApplyAuthorizationPolicyResponse{
 			Accepted: false,
-			Message:  fmt.Sprintf("Failed to marshal mesh graph: %v", err),
+			Message:  fmt.Sprintf("Failed to validate policy: %v", err),
 		}, nil
 	}
-
-	// Publish delta to Redis
-	redis := redisutil.NewRedisClient("localhost:6379")
-	if err := redis.PublishMeshDelta(ctx, delta); err != nil {
+	if len(fieldErrs) > 0 {
+		fmt.Printf("Rejected invalid policy %s/%s: %d field errors\n", req.Namespace, req.Name, len(fieldErrs))
 		return &pb.ApplyAuthorizationPolicyResponse{
 			Accepted: false,
-			Message:  fmt.Sprintf("Failed to publish mesh delta: %v", err),
+			Message:  fmt.Sprintf("Invalid AuthorizationPolicy: %s", fieldErrs[0].Message),
+			Errors:   fieldErrorsToProto(fieldErrs),
 		}, nil
 	}
 
-	fmt.Printf("Applied and published policy %s\n", policyKey)
+	authPolicy := graph.AuthPolicy{
+		Name:      req.Name,
+		Namespace: req.Namespace,
+		Spec:      spec,
+		Managed:   true,
+	}
+	obj := policy.AuthorizationPolicy(req.Namespace, req.Name, spec)
+	resp := s.applyObject(ctx, policy.AuthorizationPolicyGVR, obj, authPolicy, req.GetForce(), req.GetAdopt())
 	return &pb.ApplyAuthorizationPolicyResponse{
-		Accepted: true,
-		Message:  "Policy applied and published",
+		Accepted:        resp.accepted,
+		Message:         resp.message,
+		Errors:          resp.errors,
+		ResourceVersion: resp.resourceVersion,
+		Uid:             resp.uid,
+		Generation:      resp.generation,
+		Conflicts:       resp.conflicts,
 	}, nil
 }
 
+// applyResponse is the outcome of applyObject, as both ApplyAuthorizationPolicy
+// and the route RPCs report it
+type applyResponse struct {
+	accepted        bool
+	message         string
+	errors          []*pb.FieldError
+	resourceVersion string
+	uid             string
+	generation      int64
+	conflicts       []*pb.FieldError
+}
+
+// applyObject server-side applies obj, a validated policy or route whose
+// graph node (graph.AuthPolicy or graph.Resource) is node, and reports the
+// stored object. Without cluster access node is instead published for the
+// collector to apply. A hand-written object of the same name is only taken
+// over with adopt. The collector's informer publishes the object to every
+// server; our graph gets node now so the caller reads its own write.
+func (s *server) applyObject(ctx context.Context, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, node interface{}, force, adopt bool) applyResponse {
+	kind, key := obj.GetKind(), obj.GetNamespace()+"/"+obj.GetName()
+	var (
+		request graph.MeshGraph
+		put     func()
+		// handWritten is whether our graph's mirror of the cluster holds an
+		// unmanaged object of the same name
+		handWritten bool
+	)
+	switch n := node.(type) {
+	case graph.AuthPolicy:
+		request.AuthPolicies = map[string]graph.AuthPolicy{key: n}
+		put = func() { s.store.PutPolicy(n) }
+		existing, ok := s.store.Policies()[key]
+		handWritten = ok && !existing.Managed
+	case graph.Resource:
+		resourceKey := graph.ResourceKey(n.Namespace, n.Kind, n.Name)
+		request.Resources = map[string]graph.Resource{resourceKey: n}
+		put = func() { s.store.PutResource(n) }
+		existing, ok := s.store.Resource(resourceKey)
+		handWritten = ok && !existing.Managed
+	default:
+		return applyResponse{message: fmt.Sprintf("Cannot apply %T", node)}
+	}
+
+	if s.kube == nil {
+		if handWritten && !adopt {
+			return applyResponse{message: fmt.Sprintf("%s %s is not managed by %s; retry with adopt to take it over", kind, key, policy.FieldManager)}
+		}
+		// Publish the delta for the collector, then update our graph
+		if err := s.publishRequest(ctx, &graph.MeshGraph{}, &request); err != nil {
+			return applyResponse{message: fmt.Sprintf("Failed to publish mesh delta: %v", err)}
+		}
+		put()
+		fmt.Printf("Published %s %s for the collector to apply\n", kind, key)
+		return applyResponse{accepted: true, message: fmt.Sprintf("%s published for the collector to apply", kind)}
+	}
+
+	// A hand-written object is only taken over when adopted, even with force
+	if !adopt {
+		err := policy.CheckManaged(ctx, s.kube, gvr, obj.GetNamespace(), obj.GetName())
+		if errors.Is(err, policy.ErrNotManaged) {
+			return applyResponse{message: fmt.Sprintf("%s %s is not managed by %s; retry with adopt to take it over", kind, key, policy.FieldManager)}
+		}
+		if err != nil {
+			fmt.Printf("Failed to read %s %s: %v\n", kind, key, err)
+			return applyResponse{message: fmt.Sprintf("Failed to read %s: %v", kind, err)}
+		}
+	}
+	result, err := policy.Apply(ctx, s.kube, gvr, obj, force)
+	if err != nil {
+		fmt.Printf("Failed to apply %s %s: %v\n", kind, key, err)
+		return applyResponse{message: fmt.Sprintf("Failed to apply %s: %v", kind, err)}
+	}
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("%s %s conflicts with other field managers\n", kind, key)
+		return applyResponse{
+			message:   fmt.Sprintf("%s fields are managed by another field manager; retry with force to take them over", kind),
+			conflicts: fieldErrorsToProto(result.Conflicts),
+		}
+	}
+	if len(result.Errors) > 0 {
+		return applyResponse{
+			message: fmt.Sprintf("Invalid %s: %s", kind, result.Errors[0].Message),
+			errors:  fieldErrorsToProto(result.Errors),
+		}
+	}
+
+	put()
+	fmt.Printf("Applied %s %s (resourceVersion %s)\n", kind, key, result.ResourceVersion)
+	return applyResponse{
+		accepted:        true,
+		message:         fmt.Sprintf("%s applied (resourceVersion %s)", kind, result.ResourceVersion),
+		resourceVersion: result.ResourceVersion,
+		uid:             result.UID,
+		generation:      result.Generation,
+	}
+}
+
+// DeleteAuthorizationPolicy deletes a policy this project created, directly
+// once its removal is published so the collector does not recreate it,
+// or, without cluster access, by only publishing it for the collector.
+// Hand-written policies are refused.
+func (s *server) DeleteAuthorizationPolicy(ctx context.Context, req *pb.DeleteAuthorizationPolicyRequest) (*pb.DeleteAuthorizationPolicyResponse, error) {
+	fmt.Printf("Received DeleteAuthorizationPolicy: ns=%s name=%s\n", req.Namespace, req.Name)
+	if req.Namespace == "" || req.Name == "" {
+		return &pb.DeleteAuthorizationPolicyResponse{Message: "namespace and name are required"}, nil
+	}
+	policyKey := graph.PolicyKey(req.Namespace, req.Name)
+
+	if s.kube != nil {
+		// The collector recreates managed policies that are still requested,
+		// so their removal is requested before they are deleted
+		err := policy.CheckManaged(ctx, s.kube, policy.AuthorizationPolicyGVR, req.Namespace, req.Name)
+		if err == nil {
+			err = s.publishRequest(ctx, &graph.MeshGraph{
+				AuthPolicies: map[string]graph.AuthPolicy{policyKey: {Namespace: req.Namespace, Name: req.Name, Managed: true}},
+			}, &graph.MeshGraph{})
+		}
+		deleted := false
+		if err == nil {
+			deleted, err = policy.Delete(ctx, s.kube, policy.AuthorizationPolicyGVR, req.Namespace, req.Name)
+		}
+		switch {
+		case errors.Is(err, policy.ErrNotManaged):
+			return &pb.DeleteAuthorizationPolicyResponse{Message: fmt.Sprintf("Policy %s is not managed by %s; refusing to delete it", policyKey, policy.FieldManager)}, nil
+		case err != nil:
+			fmt.Printf("Failed to delete policy %s: %v\n", policyKey, err)
+			return &pb.DeleteAuthorizationPolicyResponse{Message: fmt.Sprintf("Failed to delete policy: %v", err)}, nil
+		case !deleted:
+			return &pb.DeleteAuthorizationPolicyResponse{Message: fmt.Sprintf("Policy %s not found", policyKey)}, nil
+		}
+		s.store.RemovePolicy(req.Namespace, req.Name)
+		fmt.Printf("Deleted policy %s\n", policyKey)
+		return &pb.DeleteAuthorizationPolicyResponse{Deleted: true, Message: "Policy deleted"}, nil
+	}
+
+	existing, ok := s.store.Policies()[policyKey]
+	if !ok {
+		return &pb.DeleteAuthorizationPolicyResponse{Message: fmt.Sprintf("Policy %s not found", policyKey)}, nil
+	}
+	if !existing.Managed {
+		return &pb.DeleteAuthorizationPolicyResponse{Message: fmt.Sprintf("Policy %s is not managed by %s; refusing to delete it", policyKey, policy.FieldManager)}, nil
+	}
+
+	// Publish the removal for the collector to prune; the policy stays in
+	// our graph unless that succeeds
+	if err := s.publishRequest(ctx, &graph.MeshGraph{
+		AuthPolicies: map[string]graph.AuthPolicy{policyKey: existing},
+	}, &graph.MeshGraph{}); err != nil {
+		return &pb.DeleteAuthorizationPolicyResponse{Message: fmt.Sprintf("Failed to publish mesh delta: %v", err)}, nil
+	}
+	s.store.RemovePolicy(req.Namespace, req.Name)
+
+	fmt.Printf("Published deletion of policy %s for the collector to apply\n", policyKey)
+	return &pb.DeleteAuthorizationPolicyResponse{Deleted: true, Message: "Deletion published for the collector to apply"}, nil
+}
+
+// publishRequest publishes the change from before to after on the backend,
+// for the collector to apply to the cluster
+func (s *server) publishRequest(ctx context.Context, before, after *graph.MeshGraph) error {
+	patch, err := graph.CreatePatch(before, after)
+	if err != nil {
+		return fmt.Errorf("building mesh delta: %w", err)
+	}
+	delta, err := json.Marshal(graph.Delta{
+		Revision: graph.Revision{Origin: s.origin, Timestamp: time.Now().UTC()},
+		Patch:    patch,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling mesh delta: %w", err)
+	}
+	_, err = s.mesh.PublishMeshDelta(ctx, delta)
+	return err
+}
+
+func fieldErrorsToProto(errs []policy.FieldError) []*pb.FieldError {
+	out := make([]*pb.FieldError, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, &pb.FieldError{Field: e.Field, Type: string(e.Type), Message: e.Message})
+	}
+	return out
+}
+
 func main() {
+	cfg := getConfigFromEnv()
+
+	// In stdio mode stdout carries the protocol, so keep log lines off it
+	stdout := os.Stdout
+	if cfg.MCPStdio {
+		os.Stdout = os.Stderr
+	}
+
 	fmt.Println("Starting MCP Server...")
 
 	// Initialize mesh graph
-	mesh := &graph.MeshGraph{
-		Services:     make(map[string]graph.Service),
-		Edges:        []graph.Edge{},
-		AuthPolicies: make(map[string]graph.AuthPolicy),
+	store := graph.NewStore()
+
+	var mesh backend.Backend
+	if cfg.Embedded {
+		// The collector writes into our store; the backend only carries
+		// policy requests to it
+		fmt.Printf("Running embedded collector (Prometheus: %s)\n", cfg.PrometheusURL)
+		mesh = backend.NewMemory()
+	} else {
+		fmt.Printf("Using backend: %s\n", cfg.BackendURL)
+		var err error
+		if mesh, err = backend.Open(cfg.BackendURL); err != nil {
+			fmt.Printf("Failed to open backend: %v\n", err)
+			os.Exit(1)
+		}
 	}
+	defer mesh.Close()
 
-	// Initialize Redis client (address would be configurable)
-	redis := redisutil.NewRedisClient("localhost:6379")
+	// Deltas carry our origin so we can skip our own echoes
+	host, _ := os.Hostname()
+	origin := fmt.Sprintf("mcp-server@%s:%d", host, os.Getpid())
 
-	// Hydrate mesh from Redis snapshot
-	snapshot, err := redis.GetMeshSnapshot(context.Background())
-	if err == nil && len(snapshot) > 0 {
-		if err := json.Unmarshal(snapshot, mesh); err != nil {
-			fmt.Printf("Failed to unmarshal mesh snapshot from Redis: %v\n", err)
-		} else {
-			fmt.Println("Hydrated mesh graph from Redis snapshot")
+	hub := newWatchHub(store)
+
+	// Policies are dry-run against the cluster when we can reach it
+	clientset, dynClient, err := kube.Clients()
+	if err != nil {
+		if cfg.Embedded {
+			fmt.Printf("Failed to connect to Kubernetes: %v\n", err)
+			os.Exit(1)
 		}
-	} else {
-		fmt.Println("No mesh snapshot found in Redis, starting with empty mesh graph")
+		fmt.Printf("Kubernetes unavailable, validating policies offline only: %v\n", err)
+	}
+	validator := policy.NewValidator(nil)
+	if cfg.DryRun && dynClient != nil {
+		validator = policy.NewValidator(dynClient)
 	}
 
-	// Subscribe to mesh:delta channel for live updates
-	go func() {
-		err := redis.SubscribeMeshDelta(context.Background(), func(msg []byte) {
-			var patch graph.MeshGraph
-			if err := json.Unmarshal(msg, &patch); err != nil {
-				fmt.Printf("Failed to unmarshal mesh delta: %v\n", err)
-				return
-			}
-			// Replace mesh with patch (future: merge/patch for efficiency)
-			*mesh = patch
-			fmt.Println("Applied mesh delta from Redis")
-		})
-		if err != nil {
-			fmt.Printf("Error subscribing to mesh:delta: %v\n", err)
-		}
-	}()
+	// Ready once the graph is loaded: from the backend's snapshot, or from
+	// the embedded collector's informers
+	checks := &admin.Checks{}
+	prometheus.MustRegister(admin.NewGraphCollector(store))
+	if cfg.Embedded {
+		// No deltas reach the store, so its own changes number the revisions
+		store.Sequence(origin)
+		startEmbeddedCollector(context.Background(), cfg, store, mesh, clientset, dynClient, checks)
+	} else {
+		checks.Add("backend", mesh.Ping)
+		checks.Add("snapshot", followBackend(store, mesh, origin).ready)
+	}
+	if cfg.AdminAddr != "" {
+		go admin.Serve(context.Background(), cfg.AdminAddr, checks)
+	}
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":10900")
 	if err != nil {
 		panic(err)
 	}
-	grpcServer := grpc.NewServer()
-	pb.RegisterMeshContextServer(grpcServer, &server{mesh: mesh})
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryMetrics),
+		grpc.ChainStreamInterceptor(streamMetrics),
+	)
+	srv := &server{
+		store:      store,
+		hub:        hub,
+		mesh:       mesh,
+		origin:     origin,
+		staleAfter: cfg.StaleAfter,
+		validator:  validator,
+		kube:       dynClient,
+	}
+	pb.RegisterMeshContextServer(grpcServer, srv)
 	// Enable gRPC reflection for introspection
 	reflection.Register(grpcServer)
+
+	// Expose the same handlers over the Model Context Protocol
+	mcpServer := mcp.NewServer(srv, version)
+	mcpServer.AllowOrigins(cfg.MCPAllowedOrigins)
+	if cfg.MCPHTTPAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/mcp", mcpServer)
+		go func() {
+			fmt.Printf("MCP streamable HTTP listening on %s/mcp\n", cfg.MCPHTTPAddr)
+			if err := http.ListenAndServe(cfg.MCPHTTPAddr, mux); err != nil {
+				fmt.Printf("MCP HTTP server error: %v\n", err)
+			}
+		}()
+	}
+	if cfg.MCPStdio {
+		go func() {
+			if err := mcpServer.ServeStdio(context.Background(), os.Stdin, stdout); err != nil {
+				fmt.Printf("MCP stdio error: %v\n", err)
+			}
+			// The client closing stdin ends the session and the process
+			fmt.Println("MCP stdio session closed, shutting down")
+			grpcServer.Stop()
+		}()
+	}
 	fmt.Println("MCP Server listening on :10900")
 	if err := grpcServer.Serve(lis); err != nil {
 		panic(err)
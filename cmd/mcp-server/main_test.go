@@ -0,0 +1,387 @@
+// cmd/mcp-server/main_test.go
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// unreachableBackend fails every publish
+type unreachableBackend struct {
+	backend.Backend
+}
+
+func (unreachableBackend) PublishMeshDelta(ctx context.Context, delta []byte) (uint64, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestGetMeshGraph_IfNewerThan(t *testing.T) {
+	store := graph.NewStore()
+	published := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	g := graph.NewStore().Snapshot()
+	g.Services["shop/web"] = graph.Service{Name: "web", Namespace: "shop"}
+	store.Replace(g, graph.Revision{Seq: 7, Origin: "collector@c-0:1", Timestamp: published})
+	srv := &server{store: store, hub: newWatchHub(store)}
+
+	resp, err := srv.GetMeshGraph(context.Background(), &pb.GetMeshGraphRequest{IfNewerThan: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetNotModified() || len(resp.GetGraph().GetServices()) != 1 {
+		t.Errorf("expected the graph for an older revision, got %+v", resp)
+	}
+	rev := resp.GetRevision()
+	if rev.GetSeq() != 7 || rev.GetOrigin() != "collector@c-0:1" || !rev.GetTimestamp().AsTime().Equal(published) {
+		t.Errorf("unexpected revision: %+v", rev)
+	}
+
+	resp, err = srv.GetMeshGraph(context.Background(), &pb.GetMeshGraphRequest{IfNewerThan: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetNotModified() || resp.GetGraph() != nil || resp.GetRevision().GetSeq() != 7 {
+		t.Errorf("expected not_modified at the current revision, got %+v", resp)
+	}
+
+	// Revisions never move backwards, e.g. on resync from an older snapshot
+	store.Replace(g, graph.Revision{Seq: 5})
+	if store.Revision().Seq != 7 {
+		t.Errorf("expected revision to stay at 7, got %d", store.Revision().Seq)
+	}
+}
+
+func TestGetMeshGraph_IfNewerThanEmbedded(t *testing.T) {
+	// An embedded collector writes straight into the store
+	store := graph.NewStore()
+	store.Sequence("collector@c-0:1")
+	store.UpsertService(graph.Service{Name: "web", Namespace: "shop"})
+	srv := &server{store: store, hub: newWatchHub(store)}
+
+	resp, err := srv.GetMeshGraph(context.Background(), &pb.GetMeshGraphRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seq := resp.GetRevision().GetSeq()
+	if seq == 0 {
+		t.Fatalf("expected a revision, got %+v", resp.GetRevision())
+	}
+	resp, err = srv.GetMeshGraph(context.Background(), &pb.GetMeshGraphRequest{IfNewerThan: seq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetNotModified() {
+		t.Errorf("expected not_modified at the current revision, got %+v", resp)
+	}
+
+	store.UpsertService(graph.Service{Name: "cart", Namespace: "shop"})
+	resp, err = srv.GetMeshGraph(context.Background(), &pb.GetMeshGraphRequest{IfNewerThan: seq})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetNotModified() || len(resp.GetGraph().GetServices()) != 2 {
+		t.Errorf("expected the changed graph, got %+v", resp)
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	store := graph.NewStore()
+	now := time.Now().UTC()
+	for name := range graph.SourceFields {
+		store.SetSource(name, graph.SourceStatus{Synced: true, LastSuccess: now})
+	}
+	srv := &server{store: store, hub: newWatchHub(store), staleAfter: time.Minute}
+
+	resp, err := srv.GetStatus(context.Background(), &pb.GetStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := resp.GetStatus(); status.GetStale() || !status.GetSynced() || len(status.GetSources()) != len(graph.SourceFields) {
+		t.Errorf("expected a fresh, synced graph, got %+v", status)
+	}
+
+	// Prometheus stopped answering five minutes ago
+	store.SetSource(graph.SourcePrometheus, graph.SourceStatus{LastSuccess: now.Add(-5 * time.Minute), Error: "connection refused"})
+	graphResp, err := srv.GetMeshGraph(context.Background(), &pb.GetMeshGraphRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := graphResp.GetStatus()
+	if !status.GetStale() || status.GetSynced() || len(status.GetStaleFields()) != 1 || status.GetStaleFields()[0] != "edges" {
+		t.Errorf("expected only edges to be stale, got %+v", status)
+	}
+	for _, src := range status.GetSources() {
+		if src.GetStale() != (src.GetName() == graph.SourcePrometheus) {
+			t.Errorf("unexpected staleness for %s: %+v", src.GetName(), src)
+		}
+	}
+}
+
+func TestApplyAuthorizationPolicy_Validation(t *testing.T) {
+	store := graph.NewStore()
+	srv := &server{store: store, hub: newWatchHub(store), mesh: backend.NewMemory(), validator: policy.NewValidator(nil)}
+
+	resp, err := srv.ApplyAuthorizationPolicy(context.Background(), &pb.ApplyAuthorizationPolicyRequest{
+		Namespace: "shop",
+		Name:      "web",
+		JsonSpec:  `{"targetRef": {"kind": "Server"}, "requiredAuthenticationRefs": []}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAccepted() || len(resp.GetErrors()) != 1 || resp.GetErrors()[0].GetField() != "spec.targetRef.name" || resp.GetErrors()[0].GetType() != "FieldValueRequired" {
+		t.Errorf("expected a missing targetRef.name to be rejected, got %+v", resp)
+	}
+	if len(store.Policies()) != 0 {
+		t.Errorf("expected the rejected policy to stay out of the graph")
+	}
+
+	resp, err = srv.ApplyAuthorizationPolicy(context.Background(), &pb.ApplyAuthorizationPolicyRequest{
+		Namespace: "shop",
+		Name:      "web",
+		JsonSpec:  `{"targetRef": {"kind": "Server", "name": "web-http"}, "requiredAuthenticationRefs": []}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetAccepted() || len(store.Policies()) != 1 {
+		t.Errorf("expected a valid policy to be accepted, got %+v", resp)
+	}
+}
+
+func TestApplyAuthorizationPolicy_Published(t *testing.T) {
+	store := graph.NewStore()
+	store.PutPolicy(graph.AuthPolicy{Name: "admin", Namespace: "shop"})
+	mesh := backend.NewMemory()
+	srv := &server{store: store, hub: newWatchHub(store), mesh: unreachableBackend{mesh}, validator: policy.NewValidator(nil)}
+	spec := `{"targetRef": {"kind": "Server", "name": "web-http"}, "requiredAuthenticationRefs": []}`
+
+	// A policy that cannot be published stays out of the graph
+	resp, err := srv.ApplyAuthorizationPolicy(context.Background(), &pb.ApplyAuthorizationPolicyRequest{Namespace: "shop", Name: "web", JsonSpec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Policies()[graph.PolicyKey("shop", "web")]; resp.GetAccepted() || ok {
+		t.Errorf("expected a failed publish to leave the graph alone, got %+v", resp)
+	}
+	srv.mesh = mesh
+
+	// A hand-written policy is only taken over when adopted
+	resp, err = srv.ApplyAuthorizationPolicy(context.Background(), &pb.ApplyAuthorizationPolicyRequest{Namespace: "shop", Name: "admin", JsonSpec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAccepted() || store.Policies()[graph.PolicyKey("shop", "admin")].Managed {
+		t.Errorf("expected an unmanaged policy to be refused, got %+v", resp)
+	}
+	resp, err = srv.ApplyAuthorizationPolicy(context.Background(), &pb.ApplyAuthorizationPolicyRequest{Namespace: "shop", Name: "admin", JsonSpec: spec, Adopt: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetAccepted() || !store.Policies()[graph.PolicyKey("shop", "admin")].Managed {
+		t.Errorf("expected an adopted policy to become managed, got %+v", resp)
+	}
+}
+
+func TestApplyAuthorizationPolicy_Direct(t *testing.T) {
+	handWritten := policy.AuthorizationPolicy("shop", "admin", map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "admin-http"}})
+	handWritten.SetLabels(nil)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), handWritten)
+	applies := 0
+	dyn.PrependReactor("patch", "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		applies++
+		obj := &unstructured.Unstructured{}
+		obj.SetResourceVersion("42")
+		obj.SetUID("3f1c")
+		return true, obj, nil
+	})
+	store := graph.NewStore()
+	mesh := backend.NewMemory()
+	srv := &server{store: store, hub: newWatchHub(store), mesh: mesh, validator: policy.NewValidator(nil), kube: dyn}
+
+	published := make(chan []byte, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mesh.SubscribeMeshDelta(ctx, func(msg []byte) { published <- msg })
+	time.Sleep(10 * time.Millisecond)
+
+	resp, err := srv.ApplyAuthorizationPolicy(context.Background(), &pb.ApplyAuthorizationPolicyRequest{
+		Namespace: "shop",
+		Name:      "web",
+		JsonSpec:  `{"targetRef": {"kind": "Server", "name": "web-http"}, "requiredAuthenticationRefs": []}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetAccepted() || resp.GetResourceVersion() != "42" || resp.GetUid() != "3f1c" {
+		t.Errorf("expected the stored object in the response, got %+v", resp)
+	}
+	if _, ok := store.Policies()[graph.PolicyKey("shop", "web")]; !ok {
+		t.Errorf("expected the applied policy in the local graph")
+	}
+
+	// A hand-written policy is only taken over when adopted
+	req := &pb.ApplyAuthorizationPolicyRequest{
+		Namespace: "shop",
+		Name:      "admin",
+		JsonSpec:  `{"targetRef": {"kind": "Server", "name": "admin-http"}, "requiredAuthenticationRefs": []}`,
+		Force:     true,
+	}
+	resp, err = srv.ApplyAuthorizationPolicy(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAccepted() || applies != 1 {
+		t.Errorf("expected an unmanaged policy to be refused even with force, got %+v", resp)
+	}
+	req.Adopt = true
+	resp, err = srv.ApplyAuthorizationPolicy(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetAccepted() || applies != 2 {
+		t.Errorf("expected an adopted policy to be applied, got %+v", resp)
+	}
+
+	// The collector's informer publishes the policy, not the server
+	select {
+	case msg := <-published:
+		t.Errorf("expected no delta for a direct apply, got %s", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDeleteAuthorizationPolicy(t *testing.T) {
+	store := graph.NewStore()
+	store.PutPolicy(graph.AuthPolicy{Name: "web", Namespace: "shop", Managed: true})
+	store.PutPolicy(graph.AuthPolicy{Name: "admin", Namespace: "shop"})
+	mesh := backend.NewMemory()
+	srv := &server{store: store, hub: newWatchHub(store), mesh: mesh, validator: policy.NewValidator(nil)}
+
+	published := make(chan []byte, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mesh.SubscribeMeshDelta(ctx, func(msg []byte) { published <- msg })
+	time.Sleep(10 * time.Millisecond)
+
+	// Hand-written policies are refused
+	resp, err := srv.DeleteAuthorizationPolicy(context.Background(), &pb.DeleteAuthorizationPolicyRequest{Namespace: "shop", Name: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetDeleted() {
+		t.Errorf("expected an unmanaged policy to be refused, got %+v", resp)
+	}
+
+	// A removal that cannot be published leaves the policy in place
+	srv.mesh = unreachableBackend{mesh}
+	resp, err = srv.DeleteAuthorizationPolicy(context.Background(), &pb.DeleteAuthorizationPolicyRequest{Namespace: "shop", Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Policies()[graph.PolicyKey("shop", "web")]; resp.GetDeleted() || !ok {
+		t.Errorf("expected shop/web to remain after a failed publish, got %+v", resp)
+	}
+	srv.mesh = mesh
+
+	// Without cluster access the removal is published for the collector
+	resp, err = srv.DeleteAuthorizationPolicy(context.Background(), &pb.DeleteAuthorizationPolicyRequest{Namespace: "shop", Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetDeleted() {
+		t.Fatalf("expected the managed policy to be deleted, got %+v", resp)
+	}
+	if _, ok := store.Policies()[graph.PolicyKey("shop", "web")]; ok {
+		t.Errorf("expected shop/web to leave the local graph")
+	}
+	select {
+	case msg := <-published:
+		var delta graph.Delta
+		if err := json.Unmarshal(msg, &delta); err != nil {
+			t.Fatal(err)
+		}
+		if len(delta.Patch) != 1 || delta.Patch[0].Op != "remove" {
+			t.Errorf("expected a single remove op, got %+v", delta.Patch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a delta for the collector")
+	}
+}
+
+func TestDeleteAuthorizationPolicy_Direct(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), policy.AuthorizationPolicy("shop", "web", map[string]interface{}{}))
+	store := graph.NewStore()
+	store.PutPolicy(graph.AuthPolicy{Name: "web", Namespace: "shop", Managed: true})
+	mesh := backend.NewMemory()
+	srv := &server{store: store, hub: newWatchHub(store), mesh: unreachableBackend{mesh}, validator: policy.NewValidator(nil), kube: dyn}
+
+	published := make(chan []byte, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mesh.SubscribeMeshDelta(ctx, func(msg []byte) { published <- msg })
+	time.Sleep(10 * time.Millisecond)
+
+	// The collector would recreate a policy whose removal it never heard of
+	resp, err := srv.DeleteAuthorizationPolicy(context.Background(), &pb.DeleteAuthorizationPolicyRequest{Namespace: "shop", Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, getErr := dyn.Resource(policy.AuthorizationPolicyGVR).Namespace("shop").Get(context.Background(), "web", metav1.GetOptions{}); resp.GetDeleted() || getErr != nil {
+		t.Errorf("expected shop/web to remain after a failed publish, got %+v (%v)", resp, getErr)
+	}
+	srv.mesh = mesh
+
+	resp, err = srv.DeleteAuthorizationPolicy(context.Background(), &pb.DeleteAuthorizationPolicyRequest{Namespace: "shop", Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetDeleted() {
+		t.Fatalf("expected the managed policy to be deleted, got %+v", resp)
+	}
+	if _, ok := store.Policies()[graph.PolicyKey("shop", "web")]; ok {
+		t.Errorf("expected shop/web to leave the local graph")
+	}
+	select {
+	case msg := <-published:
+		var delta graph.Delta
+		if err := json.Unmarshal(msg, &delta); err != nil {
+			t.Fatal(err)
+		}
+		if len(delta.Patch) != 1 || delta.Patch[0].Op != "remove" {
+			t.Errorf("expected a single remove op, got %+v", delta.Patch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the removal to be published for the collector")
+	}
+}
+
+func TestCollectorMetricsNotRegistered(t *testing.T) {
+	// The collector package is linked for embedded mode, but its metrics
+	// only appear once a collector runs
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if strings.HasPrefix(family.GetName(), "mcp_collector_") {
+			t.Errorf("unexpected collector metric %s on a server", family.GetName())
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// cmd/mcp-server/metrics.go
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_server_grpc_handled_total",
+		Help: "gRPC calls completed, by method and status code.",
+	}, []string{"method", "code"})
+	rpcDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "mcp_server_grpc_handling_seconds",
+		Help: "Latency of unary gRPC calls, by method.",
+	}, []string{"method"})
+	activeStreams = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mcp_server_grpc_active_streams",
+		Help: "Open streaming gRPC calls, by method.",
+	}, []string{"method"})
+	deltaLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "mcp_server_delta_lag_seconds",
+		Help:    "Time from a delta being produced to this server applying it.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	})
+	snapshotResyncs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_server_snapshot_resyncs_total",
+		Help: "Snapshot reloads after a missed or unappliable delta, by result (ok or error).",
+	}, []string{"result"})
+)
+
+// unaryMetrics records the latency and status of unary calls
+func unaryMetrics(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+	rpcHandled.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	return resp, err
+}
+
+// streamMetrics tracks open streams and their final status
+func streamMetrics(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	active := activeStreams.WithLabelValues(info.FullMethod)
+	active.Inc()
+	defer active.Dec()
+	err := handler(srv, ss)
+	rpcHandled.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+	return err
+}
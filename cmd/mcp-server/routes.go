@@ -0,0 +1,76 @@
+// cmd/mcp-server/routes.go
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
+)
+
+// ApplyHTTPRoute validates an HTTPRoute and applies it like a policy
+func (s *server) ApplyHTTPRoute(ctx context.Context, req *pb.ApplyRouteRequest) (*pb.ApplyRouteResponse, error) {
+	return s.applyRoute(ctx, "HTTPRoute", req)
+}
+
+// ApplyGRPCRoute validates a GRPCRoute and applies it like a policy
+func (s *server) ApplyGRPCRoute(ctx context.Context, req *pb.ApplyRouteRequest) (*pb.ApplyRouteResponse, error) {
+	return s.applyRoute(ctx, "GRPCRoute", req)
+}
+
+// applyRoute applies a route of kind to the cluster, or, without cluster
+// access, publishes it for the collector to apply
+func (s *server) applyRoute(ctx context.Context, kind string, req *pb.ApplyRouteRequest) (*pb.ApplyRouteResponse, error) {
+	fmt.Printf("Received Apply%s: ns=%s name=%s\n", kind, req.Namespace, req.Name)
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(req.JsonSpec), &spec); err != nil {
+		return &pb.ApplyRouteResponse{
+			Accepted: false,
+			Message:  fmt.Sprintf("Invalid JSON spec: %v", err),
+			Errors:   []*pb.FieldError{{Field: "spec", Type: string(metav1.CauseTypeFieldValueInvalid), Message: err.Error()}},
+		}, nil
+	}
+	fieldErrs, err := s.validator.Route(ctx, kind, req.Namespace, req.Name, spec, req.Annotations)
+	if err != nil {
+		return &pb.ApplyRouteResponse{
+			Accepted: false,
+			Message:  fmt.Sprintf("Failed to validate %s: %v", kind, err),
+		}, nil
+	}
+	if len(fieldErrs) > 0 {
+		fmt.Printf("Rejected invalid %s %s/%s: %d field errors\n", kind, req.Namespace, req.Name, len(fieldErrs))
+		return &pb.ApplyRouteResponse{
+			Accepted: false,
+			Message:  fmt.Sprintf("Invalid %s: %s", kind, fieldErrs[0].Message),
+			Errors:   fieldErrorsToProto(fieldErrs),
+		}, nil
+	}
+
+	route := graph.Resource{
+		Kind:        kind,
+		Namespace:   req.Namespace,
+		Name:        req.Name,
+		Spec:        spec,
+		Annotations: req.Annotations,
+		Managed:     true,
+	}
+	gvr, _ := policy.RouteGVR(kind)
+	obj := policy.Route(kind, req.Namespace, req.Name, spec, req.Annotations)
+	resp := s.applyObject(ctx, gvr, obj, route, req.GetForce(), req.GetAdopt())
+	return &pb.ApplyRouteResponse{
+		Accepted:        resp.accepted,
+		Message:         resp.message,
+		Errors:          resp.errors,
+		ResourceVersion: resp.resourceVersion,
+		Uid:             resp.uid,
+		Generation:      resp.generation,
+		Conflicts:       resp.conflicts,
+	}, nil
+}
@@ -0,0 +1,131 @@
+// cmd/mcp-server/routes_test.go
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestApplyRoute(t *testing.T) {
+	store := graph.NewStore()
+	mesh := backend.NewMemory()
+	srv := &server{store: store, hub: newWatchHub(store), mesh: mesh, validator: policy.NewValidator(nil)}
+
+	published := make(chan []byte, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go mesh.SubscribeMeshDelta(ctx, func(msg []byte) { published <- msg })
+	time.Sleep(10 * time.Millisecond)
+
+	// Routes must attach to a Server or Service
+	resp, err := srv.ApplyGRPCRoute(context.Background(), &pb.ApplyRouteRequest{
+		Namespace: "shop",
+		Name:      "cart",
+		JsonSpec:  `{"parentRefs": [{"kind": "Gateway", "name": "edge"}]}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAccepted() || len(resp.GetErrors()) != 1 || resp.GetErrors()[0].GetField() != "spec.parentRefs[0].kind" {
+		t.Errorf("expected the Gateway parent to be rejected, got %+v", resp)
+	}
+
+	// A route that cannot be published stays out of the graph
+	req := &pb.ApplyRouteRequest{
+		Namespace:   "shop",
+		Name:        "web",
+		JsonSpec:    `{"parentRefs": [{"kind": "Service", "name": "web", "port": 8080}], "rules": [{"backendRefs": [{"name": "web-v1", "port": 8080, "weight": 90}, {"name": "web-v2", "port": 8080, "weight": 10}]}]}`,
+		Annotations: map[string]string{"retry.linkerd.io/http": "5xx", "timeout.linkerd.io/request": "2s"},
+	}
+	srv.mesh = unreachableBackend{mesh}
+	resp, err = srv.ApplyHTTPRoute(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Resource(graph.ResourceKey("shop", "HTTPRoute", "web")); resp.GetAccepted() || ok {
+		t.Errorf("expected a failed publish to leave the graph alone, got %+v", resp)
+	}
+	srv.mesh = mesh
+
+	// Without cluster access a valid route is published for the collector
+	resp, err = srv.ApplyHTTPRoute(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetAccepted() {
+		t.Fatalf("expected the route to be accepted, got %+v", resp)
+	}
+	var route graph.Resource
+	store.View(func(g *graph.MeshGraph) { route = g.Resources[graph.ResourceKey("shop", "HTTPRoute", "web")] })
+	if !route.Managed || route.Annotations["retry.linkerd.io/http"] != "5xx" {
+		t.Errorf("expected the managed route in the local graph, got %+v", route)
+	}
+	select {
+	case msg := <-published:
+		var delta graph.Delta
+		if err := json.Unmarshal(msg, &delta); err != nil {
+			t.Fatal(err)
+		}
+		if len(delta.Patch) != 1 || delta.Patch[0].Path != "/Resources/shop~1httproute~1web" {
+			t.Errorf("expected the route in the delta, got %+v", delta.Patch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a delta for the collector")
+	}
+}
+
+func TestApplyRoute_Adopt(t *testing.T) {
+	spec := map[string]interface{}{"parentRefs": []interface{}{map[string]interface{}{"kind": "Service", "name": "web"}}}
+	handWritten := policy.Route("HTTPRoute", "shop", "web", spec, nil)
+	handWritten.SetLabels(nil)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), handWritten)
+	applies := 0
+	dyn.PrependReactor("patch", "httproutes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		applies++
+		return true, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+	})
+	store := graph.NewStore()
+	store.PutResource(graph.Resource{Kind: "GRPCRoute", Namespace: "shop", Name: "cart"})
+	srv := &server{store: store, hub: newWatchHub(store), mesh: backend.NewMemory(), validator: policy.NewValidator(nil), kube: dyn}
+
+	// Force takes over fields, not hand-written routes
+	req := &pb.ApplyRouteRequest{Namespace: "shop", Name: "web", JsonSpec: `{"parentRefs": [{"kind": "Service", "name": "web"}]}`, Force: true}
+	resp, err := srv.ApplyHTTPRoute(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetAccepted() || applies != 0 {
+		t.Errorf("expected an unmanaged route to be refused, got %+v", resp)
+	}
+	req.Adopt = true
+	resp, err = srv.ApplyHTTPRoute(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.GetAccepted() || applies != 1 {
+		t.Errorf("expected an adopted route to be applied, got %+v", resp)
+	}
+
+	// Without cluster access the graph's mirror is checked instead
+	srv.kube = nil
+	resp, err = srv.ApplyGRPCRoute(context.Background(), &pb.ApplyRouteRequest{Namespace: "shop", Name: "cart", JsonSpec: `{"parentRefs": [{"kind": "Service", "name": "cart"}]}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route, _ := store.Resource(graph.ResourceKey("shop", "GRPCRoute", "cart")); resp.GetAccepted() || route.Managed {
+		t.Errorf("expected an unmanaged route in the graph to be refused, got %+v", resp)
+	}
+}
@@ -0,0 +1,175 @@
+// cmd/mcp-server/sync.go
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+// followBackend hydrates store from the backend's snapshot and keeps it in
+// sync with the published deltas
+func followBackend(store *graph.Store, mesh backend.Backend, origin string) *deltaFollower {
+	follower := &deltaFollower{
+		store:  store,
+		origin: origin,
+		loadSnapshot: func() (*graph.Snapshot, error) {
+			data, err := mesh.GetMeshSnapshot(context.Background())
+			if err != nil || len(data) == 0 {
+				return nil, err
+			}
+			return graph.DecodeSnapshot(data)
+		},
+	}
+	if found, err := follower.hydrate(); err != nil {
+		fmt.Printf("Failed to hydrate mesh graph from snapshot: %v\n", err)
+	} else if found {
+		fmt.Println("Hydrated mesh graph from snapshot")
+	} else {
+		fmt.Println("No mesh snapshot found, starting with empty mesh graph")
+	}
+
+	// Subscribe to mesh:delta channel for live updates
+	go func() {
+		err := mesh.SubscribeMeshDelta(context.Background(), func(msg []byte) {
+			if err := follower.handle(msg); err != nil {
+				fmt.Printf("Failed to apply mesh delta: %v\n", err)
+			}
+		})
+		if err != nil {
+			fmt.Printf("Error subscribing to mesh:delta: %v\n", err)
+		}
+	}()
+	return follower
+}
+
+// deltaFollower applies mesh:delta messages to the store in sequence order,
+// re-reading mesh:snapshot whenever a gap shows that deltas were missed or
+// the sequence went back because the backend was reset.
+type deltaFollower struct {
+	store  *graph.Store
+	origin string
+	// loadSnapshot fetches the current mesh:snapshot (nil when absent)
+	loadSnapshot func() (*graph.Snapshot, error)
+
+	mu sync.Mutex
+	// seq is the sequence number of the last delta reflected in the store
+	seq uint64
+	// hydrated is set once the store holds a full graph, from a snapshot or
+	// the deltas since the first one
+	hydrated bool
+}
+
+// ready reports an error until the store has been hydrated
+func (f *deltaFollower) ready(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.hydrated {
+		return errors.New("no mesh snapshot loaded yet")
+	}
+	return nil
+}
+
+// hydrate replaces the store content with the current snapshot, reporting
+// whether one was found
+func (f *deltaFollower) hydrate() (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.resync()
+}
+
+// resync reloads the snapshot, leaving the store alone when there is none;
+// callers hold f.mu
+func (f *deltaFollower) resync() (bool, error) {
+	snap, err := f.loadSnapshot()
+	if err != nil || snap == nil {
+		return false, err
+	}
+	f.store.Replace(snap.Graph, snap.Revision)
+	f.seq = snap.Seq
+	f.hydrated = true
+	return true, nil
+}
+
+// handle processes one mesh:delta message
+func (f *deltaFollower) handle(msg []byte) error {
+	var delta graph.Delta
+	if err := json.Unmarshal(msg, &delta); err != nil {
+		return fmt.Errorf("invalid delta: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resynced := false
+	switch {
+	case delta.Seq != 0 && f.seq > 0 && (delta.Seq < f.seq || delta.Seq == 1):
+		// The sequence starts over when the backend loses its data (e.g. a
+		// Redis flush), or this delta is one a snapshot we loaded covers
+		fmt.Printf("Mesh delta sequence went back (have %d, got %d), re-reading snapshot\n", f.seq, delta.Seq)
+		found, err := f.countedResync()
+		if err != nil {
+			return fmt.Errorf("resync after sequence reset: %w", err)
+		}
+		if found && delta.Seq <= f.seq {
+			return nil
+		}
+		// Without a newer snapshot, follow the new sequence on top of the
+		// graph we have
+		resynced = true
+	case delta.Seq != 0 && delta.Seq <= f.seq:
+		// Already covered by the snapshot we loaded
+		return nil
+	case delta.Seq == 0 || delta.Seq > f.seq+1:
+		fmt.Printf("Mesh delta gap (have %d, got %d), re-reading snapshot\n", f.seq, delta.Seq)
+		if _, err := f.countedResync(); err != nil {
+			return fmt.Errorf("resync after gap: %w", err)
+		}
+		if delta.Seq <= f.seq {
+			return nil
+		}
+		// The snapshot may predate this delta (e.g. one published by a
+		// server rather than the collector), so apply it on top
+		resynced = true
+	}
+	// Our own deltas are already in the store, unless a resync replaced it
+	if delta.Origin != f.origin || resynced {
+		if err := f.store.ApplyPatch(delta.Patch, delta.Revision); err != nil {
+			fmt.Printf("Failed to apply mesh delta %d: %v\n", delta.Seq, err)
+			// Our copy diverged; the snapshot is authoritative
+			if _, err := f.countedResync(); err != nil {
+				return fmt.Errorf("resync after failed patch: %w", err)
+			}
+			return nil
+		}
+	} else {
+		f.store.Advance(delta.Revision)
+	}
+	f.seq = delta.Seq
+	// The first delta ever published holds the whole graph
+	if delta.Seq == 1 {
+		f.hydrated = true
+	}
+	if !delta.Timestamp.IsZero() {
+		deltaLag.Observe(time.Since(delta.Timestamp).Seconds())
+	}
+	return nil
+}
+
+// countedResync is resync, recorded in the resync metrics
+func (f *deltaFollower) countedResync() (bool, error) {
+	found, err := f.resync()
+	if err != nil {
+		snapshotResyncs.WithLabelValues("error").Inc()
+	} else {
+		snapshotResyncs.WithLabelValues("ok").Inc()
+	}
+	return found, err
+}
@@ -0,0 +1,146 @@
+// cmd/mcp-server/sync_test.go
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+func deltaMessage(t *testing.T, seq uint64, origin string, old, new *graph.MeshGraph) []byte {
+	t.Helper()
+	patch, err := graph.CreatePatch(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := json.Marshal(graph.Delta{Revision: graph.Revision{Seq: seq, Origin: origin}, Patch: patch})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestDeltaFollower(t *testing.T) {
+	remote := graph.NewStore()
+	remote.UpsertService(graph.Service{Name: "web", Namespace: "shop"})
+	snapshot := &graph.Snapshot{Revision: graph.Revision{Seq: 3}, Graph: remote.Snapshot()}
+	loads := 0
+
+	f := &deltaFollower{
+		store:  graph.NewStore(),
+		origin: "me",
+		loadSnapshot: func() (*graph.Snapshot, error) {
+			loads++
+			return snapshot, nil
+		},
+	}
+	if err := f.ready(context.Background()); err == nil {
+		t.Errorf("expected the follower not to be ready before hydrating")
+	}
+	if found, err := f.hydrate(); err != nil || !found {
+		t.Fatalf("hydrate: found=%t err=%v", found, err)
+	}
+	if err := f.ready(context.Background()); err != nil {
+		t.Errorf("expected the follower to be ready after hydrating: %v", err)
+	}
+
+	// In-order delta is applied
+	before := remote.Snapshot()
+	remote.UpsertService(graph.Service{Name: "cart", Namespace: "shop"})
+	if err := f.handle(deltaMessage(t, 4, "collector", before, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.store.Snapshot().Services["shop/cart"]; !ok || f.seq != 4 {
+		t.Fatalf("expected delta 4 to be applied, seq=%d", f.seq)
+	}
+
+	// Duplicates and our own deltas are skipped
+	if err := f.handle(deltaMessage(t, 4, "collector", before, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.handle(deltaMessage(t, 5, "me", &graph.MeshGraph{}, &graph.MeshGraph{
+		Services: map[string]graph.Service{"shop/mine": {Name: "mine", Namespace: "shop"}},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.store.Snapshot().Services["shop/mine"]; ok || f.seq != 5 {
+		t.Errorf("expected own delta to be skipped but counted, seq=%d", f.seq)
+	}
+
+	// A gap triggers a snapshot reload
+	remote.RemoveService("shop", "web")
+	snapshot = &graph.Snapshot{Revision: graph.Revision{Seq: 9}, Graph: remote.Snapshot()}
+	before = remote.Snapshot()
+	remote.UpsertService(graph.Service{Name: "web", Namespace: "shop", Meshed: true})
+	if err := f.handle(deltaMessage(t, 10, "collector", before, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 || f.seq != 10 {
+		t.Errorf("expected a resync before delta 10, loads=%d seq=%d", loads, f.seq)
+	}
+	if changes := graph.Diff(remote.Snapshot(), f.store.Snapshot()); len(changes) != 0 {
+		t.Errorf("follower diverged from remote: %+v", changes)
+	}
+}
+
+func TestDeltaFollowerSequenceReset(t *testing.T) {
+	remote := graph.NewStore()
+	remote.UpsertService(graph.Service{Name: "web", Namespace: "shop"})
+	snapshot := &graph.Snapshot{Revision: graph.Revision{Seq: 42}, Graph: remote.Snapshot()}
+	loads := 0
+
+	f := &deltaFollower{
+		store:  graph.NewStore(),
+		origin: "me",
+		loadSnapshot: func() (*graph.Snapshot, error) {
+			loads++
+			return snapshot, nil
+		},
+	}
+	if _, err := f.hydrate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A delta from before the snapshot we loaded is skipped
+	if err := f.handle(deltaMessage(t, 41, "collector", &graph.MeshGraph{}, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 || f.seq != 42 {
+		t.Errorf("expected a stale delta to be checked against the snapshot, loads=%d seq=%d", loads, f.seq)
+	}
+
+	// The backend lost its data: the sequence starts over without a snapshot
+	snapshot = nil
+	before := remote.Snapshot()
+	remote.UpsertService(graph.Service{Name: "cart", Namespace: "shop"})
+	if err := f.handle(deltaMessage(t, 1, "server", before, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.store.Snapshot().Services["shop/cart"]; !ok || f.seq != 1 {
+		t.Fatalf("expected delta 1 to be applied after the reset, seq=%d", f.seq)
+	}
+	before = remote.Snapshot()
+	remote.UpsertService(graph.Service{Name: "web", Namespace: "shop", Meshed: true})
+	if err := f.handle(deltaMessage(t, 2, "collector", before, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if f.seq != 2 {
+		t.Errorf("expected the new sequence to be followed, seq=%d", f.seq)
+	}
+
+	// Once the collector stores a snapshot again it wins
+	remote.RemoveService("shop", "cart")
+	snapshot = &graph.Snapshot{Revision: graph.Revision{Seq: 3}, Graph: remote.Snapshot()}
+	if err := f.handle(deltaMessage(t, 1, "collector", &graph.MeshGraph{}, remote.Snapshot())); err != nil {
+		t.Fatal(err)
+	}
+	if f.seq != 3 {
+		t.Errorf("expected the follower to move to the snapshot, seq=%d", f.seq)
+	}
+	if changes := graph.Diff(remote.Snapshot(), f.store.Snapshot()); len(changes) != 0 {
+		t.Errorf("follower diverged from remote: %+v", changes)
+	}
+}
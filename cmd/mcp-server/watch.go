@@ -0,0 +1,202 @@
+// cmd/mcp-server/watch.go
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+const (
+	// watchHistorySize is how many events are kept for resuming watchers
+	watchHistorySize = 1024
+	// watcherBufferSize is how far a watcher may fall behind before it is dropped
+	watcherBufferSize = 256
+)
+
+// watchHub versions every change made to the server's graph store and fans
+// the resulting events out to WatchMeshGraph streams.
+type watchHub struct {
+	store *graph.Store
+	// epoch is unique to this hub, so versions numbered by another server
+	// or an earlier process are never mistaken for ours
+	epoch    string
+	mu       sync.RWMutex
+	version  uint64
+	history  []*pb.MeshGraphEvent
+	watchers map[*watcher]struct{}
+}
+
+type watcher struct {
+	events chan *pb.MeshGraphEvent
+	// dropped is set (under the hub lock) when the watcher could not keep up
+	dropped bool
+}
+
+func newWatchHub(store *graph.Store) *watchHub {
+	epoch := make([]byte, 8)
+	_, _ = rand.Read(epoch)
+	h := &watchHub{
+		store:    store,
+		epoch:    hex.EncodeToString(epoch),
+		watchers: make(map[*watcher]struct{}),
+	}
+	store.Observe(h.publish)
+	return h
+}
+
+// publish broadcasts the changes of one store mutation. It runs under the
+// store lock, which keeps events in the order the changes were made.
+func (h *watchHub) publish(changes []graph.Change) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, change := range changes {
+		// Freshness heartbeats are served by GetStatus, not streamed
+		if change.Type == graph.SourceUpdated {
+			continue
+		}
+		event, err := changeToEvent(change)
+		if err != nil {
+			fmt.Printf("Watch: skipping change %s: %v\n", change.Key, err)
+			continue
+		}
+		h.version++
+		event.Version = h.version
+		event.Epoch = h.epoch
+		h.history = append(h.history, event)
+		if len(h.history) > watchHistorySize {
+			h.history = h.history[len(h.history)-watchHistorySize:]
+		}
+		for w := range h.watchers {
+			select {
+			case w.events <- event:
+			default:
+				// Too slow: cut it off, the client can resume from its last version
+				w.dropped = true
+				close(w.events)
+				delete(h.watchers, w)
+			}
+		}
+	}
+}
+
+// subscribe registers a watcher and returns the events it must be sent first:
+// the buffered events after resumeFrom, or a full snapshot when those are
+// no longer (or were never) available or were numbered in another epoch.
+func (h *watchHub) subscribe(resumeFrom uint64, epoch string) (w *watcher, backlog []*pb.MeshGraphEvent, err error) {
+	// Lock order is store then hub, the same as publish
+	h.store.View(func(mesh *graph.MeshGraph) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if resumeFrom > 0 && epoch == h.epoch && resumeFrom <= h.version && h.canResume(resumeFrom) {
+			for _, event := range h.history {
+				if event.Version > resumeFrom {
+					backlog = append(backlog, event)
+				}
+			}
+		} else {
+			var snapshot *pb.MeshGraph
+			snapshot, err = graph.ToProto(mesh)
+			if err != nil {
+				return
+			}
+			backlog = []*pb.MeshGraphEvent{{
+				Version:  h.version,
+				Epoch:    h.epoch,
+				Type:     pb.MeshGraphEvent_SNAPSHOT,
+				Snapshot: snapshot,
+			}}
+		}
+
+		w = &watcher{events: make(chan *pb.MeshGraphEvent, watcherBufferSize)}
+		h.watchers[w] = struct{}{}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return w, backlog, nil
+}
+
+func (h *watchHub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.watchers[w]; ok {
+		delete(h.watchers, w)
+		close(w.events)
+	}
+}
+
+// isDropped reports whether the hub cut the watcher off for being too slow
+func (h *watchHub) isDropped(w *watcher) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return w.dropped
+}
+
+// canResume reports whether every event after version is still buffered
+func (h *watchHub) canResume(version uint64) bool {
+	if version == h.version {
+		return true
+	}
+	return len(h.history) > 0 && h.history[0].Version <= version+1
+}
+
+func changeToEvent(c graph.Change) (*pb.MeshGraphEvent, error) {
+	event := &pb.MeshGraphEvent{Key: c.Key}
+	switch c.Type {
+	case graph.ServiceAdded:
+		event.Type = pb.MeshGraphEvent_SERVICE_ADDED
+	case graph.ServiceUpdated:
+		event.Type = pb.MeshGraphEvent_SERVICE_UPDATED
+	case graph.ServiceRemoved:
+		event.Type = pb.MeshGraphEvent_SERVICE_REMOVED
+	case graph.EdgeAdded:
+		event.Type = pb.MeshGraphEvent_EDGE_ADDED
+	case graph.EdgeUpdated:
+		event.Type = pb.MeshGraphEvent_EDGE_UPDATED
+	case graph.EdgeRemoved:
+		event.Type = pb.MeshGraphEvent_EDGE_REMOVED
+	case graph.PolicyApplied:
+		event.Type = pb.MeshGraphEvent_POLICY_APPLIED
+	case graph.PolicyRemoved:
+		event.Type = pb.MeshGraphEvent_POLICY_REMOVED
+	case graph.WorkloadAdded:
+		event.Type = pb.MeshGraphEvent_WORKLOAD_ADDED
+	case graph.WorkloadUpdated:
+		event.Type = pb.MeshGraphEvent_WORKLOAD_UPDATED
+	case graph.WorkloadRemoved:
+		event.Type = pb.MeshGraphEvent_WORKLOAD_REMOVED
+	case graph.ResourceApplied:
+		event.Type = pb.MeshGraphEvent_RESOURCE_APPLIED
+	case graph.ResourceRemoved:
+		event.Type = pb.MeshGraphEvent_RESOURCE_REMOVED
+	}
+	switch {
+	case c.Service != nil:
+		event.Service = graph.ServiceToProto(*c.Service)
+	case c.Edge != nil:
+		event.Edge = graph.EdgeToProto(*c.Edge)
+	case c.Workload != nil:
+		event.Workload = graph.WorkloadToProto(*c.Workload)
+	case c.Policy != nil:
+		policy, err := graph.PolicyToProto(*c.Policy)
+		if err != nil {
+			return nil, err
+		}
+		event.Policy = policy
+	case c.Resource != nil:
+		res, err := graph.ResourceToProto(*c.Resource)
+		if err != nil {
+			return nil, err
+		}
+		event.Resource = res
+	}
+	return event, nil
+}
@@ -0,0 +1,67 @@
+// cmd/mcp-server/watch_test.go
+
+package main
+
+import (
+	"testing"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+func newTestHub() *watchHub {
+	return newWatchHub(graph.NewStore())
+}
+
+func TestWatchHub_SnapshotThenEvents(t *testing.T) {
+	hub := newTestHub()
+	w, backlog, err := hub.subscribe(0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backlog) != 1 || backlog[0].Type != pb.MeshGraphEvent_SNAPSHOT {
+		t.Fatalf("expected an initial snapshot, got %+v", backlog)
+	}
+
+	hub.store.UpsertService(graph.Service{Name: "web", Namespace: "shop"})
+	event := <-w.events
+	if event.Type != pb.MeshGraphEvent_SERVICE_ADDED || event.Key != "shop/web" || event.Version != 1 {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	hub.unsubscribe(w)
+	if _, ok := <-w.events; ok {
+		t.Errorf("expected channel to be closed after unsubscribe")
+	}
+}
+
+func TestWatchHub_Resume(t *testing.T) {
+	hub := newTestHub()
+	for _, name := range []string{"a", "b", "c"} {
+		hub.store.UpsertService(graph.Service{Name: name, Namespace: "default"})
+	}
+
+	_, backlog, err := hub.subscribe(1, hub.epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backlog) != 2 || backlog[0].Key != "default/b" || backlog[1].Key != "default/c" || backlog[0].Epoch != hub.epoch {
+		t.Errorf("expected events after version 1 to be replayed, got %+v", backlog)
+	}
+
+	// A version from a previous server incarnation falls back to a snapshot,
+	// even when this one has already reached it
+	for _, epoch := range []string{hub.epoch, newTestHub().epoch, ""} {
+		resumeFrom := uint64(42)
+		if epoch != hub.epoch {
+			resumeFrom = 1
+		}
+		_, backlog, err = hub.subscribe(resumeFrom, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(backlog) != 1 || backlog[0].Type != pb.MeshGraphEvent_SNAPSHOT || backlog[0].Version != 3 || backlog[0].Epoch != hub.epoch {
+			t.Errorf("resuming %d in epoch %q: expected snapshot at version 3, got %+v", resumeFrom, epoch, backlog)
+		}
+	}
+}
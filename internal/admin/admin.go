@@ -0,0 +1,110 @@
+// internal/admin/admin.go
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// checkTimeout bounds each readiness check
+const checkTimeout = 2 * time.Second
+
+// Checks is a set of named readiness checks. The zero value is ready to use.
+type Checks struct {
+	mu     sync.Mutex
+	checks map[string]func(context.Context) error
+}
+
+// Add registers check under name, replacing any check of that name
+func (c *Checks) Add(name string, check func(context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.checks == nil {
+		c.checks = make(map[string]func(context.Context) error)
+	}
+	c.checks[name] = check
+}
+
+// result is the outcome of one check
+type result struct {
+	name string
+	err  error
+}
+
+// run runs every check concurrently, returning the results sorted by name
+func (c *Checks) run(ctx context.Context) []result {
+	c.mu.Lock()
+	results := make([]result, 0, len(c.checks))
+	checks := make([]func(context.Context) error, 0, len(c.checks))
+	for name, check := range c.checks {
+		results = append(results, result{name: name})
+		checks = append(checks, check)
+	}
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].err = checks[i](ctx)
+		}()
+	}
+	wg.Wait()
+	sort.Slice(results, func(i, j int) bool { return results[i].name < results[j].name })
+	return results
+}
+
+// Handler serves the admin endpoints:
+//
+//	/healthz  200 while the process serves HTTP
+//	/ready    200 when every check passes, 503 otherwise; lists each check
+//	/metrics  Prometheus metrics from gatherer
+func Handler(checks *Checks, gatherer prometheus.Gatherer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		var b strings.Builder
+		ready := true
+		for _, res := range checks.run(r.Context()) {
+			if res.err != nil {
+				ready = false
+				fmt.Fprintf(&b, "[-] %s: %v\n", res.name, res.err)
+			} else {
+				fmt.Fprintf(&b, "[+] %s ok\n", res.name)
+			}
+		}
+		if !ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		fmt.Fprint(w, b.String())
+	})
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	return mux
+}
+
+// Serve runs the admin endpoints on addr until ctx is done
+func Serve(ctx context.Context, addr string, checks *Checks) {
+	srv := &http.Server{Addr: addr, Handler: Handler(checks, prometheus.DefaultGatherer)}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	fmt.Printf("Admin endpoints listening on %s (/healthz, /ready, /metrics)\n", addr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Printf("Admin server error: %v\n", err)
+	}
+}
@@ -0,0 +1,55 @@
+// internal/admin/admin_test.go
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	body, _ := io.ReadAll(rec.Result().Body)
+	return rec.Code, string(body)
+}
+
+func TestHandler(t *testing.T) {
+	store := graph.NewStore()
+	store.UpsertService(graph.Service{Name: "web", Namespace: "shop"})
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewGraphCollector(store))
+
+	var checks Checks
+	backendErr := errors.New("connection refused")
+	checks.Add("backend", func(ctx context.Context) error { return backendErr })
+	checks.Add("informers", func(ctx context.Context) error { return nil })
+	h := Handler(&checks, reg)
+
+	if code, _ := get(t, h, "/healthz"); code != http.StatusOK {
+		t.Errorf("expected /healthz to be 200, got %d", code)
+	}
+	code, body := get(t, h, "/ready")
+	if code != http.StatusServiceUnavailable || !strings.Contains(body, "[-] backend: connection refused") || !strings.Contains(body, "[+] informers ok") {
+		t.Errorf("expected /ready to fail on the backend, got %d %q", code, body)
+	}
+	backendErr = nil
+	if code, body := get(t, h, "/ready"); code != http.StatusOK {
+		t.Errorf("expected /ready to pass, got %d %q", code, body)
+	}
+
+	_, body = get(t, h, "/metrics")
+	if !strings.Contains(body, `mcp_graph_objects{kind="services"} 1`) {
+		t.Errorf("expected the service count in /metrics, got:\n%s", body)
+	}
+}
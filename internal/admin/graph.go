@@ -0,0 +1,56 @@
+// internal/admin/graph.go
+
+package admin
+
+import (
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	graphObjectsDesc = prometheus.NewDesc("mcp_graph_objects",
+		"Number of objects in the mesh graph, by kind.", []string{"kind"}, nil)
+	graphSeqDesc = prometheus.NewDesc("mcp_graph_revision_seq",
+		"Sequence number of the last delta reflected in the mesh graph.", nil, nil)
+	graphAgeDesc = prometheus.NewDesc("mcp_graph_revision_age_seconds",
+		"Time since the mesh graph's revision was produced.", nil, nil)
+)
+
+// graphCollector reports the size and revision of a store at scrape time
+type graphCollector struct {
+	store *graph.Store
+}
+
+// NewGraphCollector returns a collector of mcp_graph_* metrics for store.
+// Register one per process.
+func NewGraphCollector(store *graph.Store) prometheus.Collector {
+	return graphCollector{store: store}
+}
+
+func (c graphCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- graphObjectsDesc
+	ch <- graphSeqDesc
+	ch <- graphAgeDesc
+}
+
+func (c graphCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := make(map[string]int)
+	c.store.View(func(g *graph.MeshGraph) {
+		counts["services"] = len(g.Services)
+		counts["edges"] = len(g.Edges)
+		counts["workloads"] = len(g.Workloads)
+		counts["auth_policies"] = len(g.AuthPolicies)
+		counts["resources"] = len(g.Resources)
+	})
+	for kind, n := range counts {
+		ch <- prometheus.MustNewConstMetric(graphObjectsDesc, prometheus.GaugeValue, float64(n), kind)
+	}
+	rev := c.store.Revision()
+	ch <- prometheus.MustNewConstMetric(graphSeqDesc, prometheus.GaugeValue, float64(rev.Seq))
+	if !rev.Timestamp.IsZero() {
+		ch <- prometheus.MustNewConstMetric(graphAgeDesc, prometheus.GaugeValue, time.Since(rev.Timestamp).Seconds())
+	}
+}
@@ -0,0 +1,80 @@
+// internal/backend/backend.go
+
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+	redisutil "github.com/eli-nomasec/linkerd2-mcp/internal/redis"
+)
+
+// Backend carries the mesh graph from the collector to the servers: the
+// latest snapshot, the stream of sequenced deltas, and the collector lease.
+type Backend interface {
+	// GetMeshSnapshot returns the stored snapshot, or nil when there is none
+	GetMeshSnapshot(ctx context.Context) ([]byte, error)
+	// PublishMeshDelta publishes delta and returns the sequence number it was
+	// assigned. delta must be a non-empty JSON object without a "seq" member.
+	PublishMeshDelta(ctx context.Context, delta []byte) (uint64, error)
+	// PublishMeshUpdate stores snapshot and publishes delta under the same
+	// sequence number, which it returns. Both are stamped as for PublishMeshDelta.
+	PublishMeshUpdate(ctx context.Context, snapshot, delta []byte, ttl time.Duration) (uint64, error)
+	// RefreshMeshSnapshot extends the snapshot's expiration without changing it
+	RefreshMeshSnapshot(ctx context.Context, ttl time.Duration) error
+	// SubscribeMeshDelta calls handler with each delta, carrying its "seq",
+	// until ctx is done
+	SubscribeMeshDelta(ctx context.Context, handler func([]byte)) error
+	// NewElector elects a leader among the processes sharing the backend
+	NewElector(id string, ttl time.Duration, callbacks leader.Callbacks) leader.Elector
+	// Ping checks that the backend is reachable
+	Ping(ctx context.Context) error
+	// Close releases the connection
+	Close() error
+}
+
+var _ Backend = (*redisutil.RedisClient)(nil)
+
+// Open connects to the backend addressed by url:
+//
+//	host:port, redis://..., rediss://...  Redis
+//	nats://...                            NATS JetStream
+//	memory://                             in-process, for a single binary
+func Open(url string) (Backend, error) {
+	scheme, _, found := strings.Cut(url, "://")
+	if !found {
+		return redisutil.NewRedisClient(url), nil
+	}
+	switch scheme {
+	case "redis", "rediss":
+		client, err := redisutil.NewRedisClientURL(url)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case "nats":
+		client, err := NewNATS(url)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case "memory":
+		return NewMemory(), nil
+	default:
+		return nil, fmt.Errorf("unsupported backend %q", url)
+	}
+}
+
+// stampSeq splices seq into the JSON object payload as a leading "seq"
+// member, as the Redis publish script does
+func stampSeq(payload []byte, seq uint64) []byte {
+	out := make([]byte, 0, len(payload)+24)
+	out = append(out, `{"seq":`...)
+	out = strconv.AppendUint(out, seq, 10)
+	out = append(out, ',')
+	return append(out, payload[1:]...)
+}
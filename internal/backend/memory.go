@@ -0,0 +1,142 @@
+// internal/backend/memory.go
+
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+)
+
+// subscriberBuffer is how many deltas a slow subscriber may lag behind
+// before it misses some; the sequence gap then makes it re-read the snapshot
+const subscriberBuffer = 256
+
+// Memory is a Backend inside one process, for running the collector and the
+// server in a single binary
+type Memory struct {
+	mu          sync.Mutex
+	seq         uint64
+	snapshot    []byte
+	expires     time.Time
+	subscribers map[chan []byte]struct{}
+
+	holder       string
+	leaseExpires time.Time
+}
+
+// NewMemory returns an empty in-process backend
+func NewMemory() *Memory {
+	return &Memory{subscribers: make(map[chan []byte]struct{})}
+}
+
+func (m *Memory) GetMeshSnapshot(ctx context.Context) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.snapshot == nil || !time.Now().Before(m.expires) {
+		return nil, nil
+	}
+	return append([]byte(nil), m.snapshot...), nil
+}
+
+func (m *Memory) PublishMeshDelta(ctx context.Context, delta []byte) (uint64, error) {
+	return m.publish(nil, delta, 0), nil
+}
+
+func (m *Memory) PublishMeshUpdate(ctx context.Context, snapshot, delta []byte, ttl time.Duration) (uint64, error) {
+	return m.publish(snapshot, delta, ttl), nil
+}
+
+// publish takes the next sequence number and fans the delta out while
+// holding the lock, so subscribers see deltas in sequence order
+func (m *Memory) publish(snapshot, delta []byte, ttl time.Duration) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq++
+	if snapshot != nil {
+		m.snapshot = stampSeq(snapshot, m.seq)
+		m.expires = time.Now().Add(ttl)
+	}
+	msg := stampSeq(delta, m.seq)
+	for ch := range m.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			// Dropped like a lost pub/sub message; the subscriber resyncs
+		}
+	}
+	return m.seq
+}
+
+func (m *Memory) RefreshMeshSnapshot(ctx context.Context, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.snapshot != nil {
+		m.expires = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+func (m *Memory) SubscribeMeshDelta(ctx context.Context, handler func([]byte)) error {
+	ch := make(chan []byte, subscriberBuffer)
+	m.mu.Lock()
+	m.subscribers[ch] = struct{}{}
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.subscribers, ch)
+		m.mu.Unlock()
+	}()
+	for {
+		select {
+		case msg := <-ch:
+			handler(msg)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *Memory) NewElector(id string, ttl time.Duration, callbacks leader.Callbacks) leader.Elector {
+	return leader.NewLease(m, "memory lease", id, ttl, callbacks)
+}
+
+func (m *Memory) TryAcquireLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder != "" && m.holder != holder && time.Now().Before(m.leaseExpires) {
+		return false, nil
+	}
+	m.holder = holder
+	m.leaseExpires = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (m *Memory) RenewLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder != holder || !time.Now().Before(m.leaseExpires) {
+		return false, nil
+	}
+	m.leaseExpires = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (m *Memory) ReleaseLeader(ctx context.Context, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder == holder {
+		m.holder = ""
+	}
+	return nil
+}
+
+func (m *Memory) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
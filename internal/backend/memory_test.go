@@ -0,0 +1,112 @@
+// internal/backend/memory_test.go
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMemory_PublishAndSubscribe(t *testing.T) {
+	m := NewMemory()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := make(chan []byte, 2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = m.SubscribeMeshDelta(ctx, func(msg []byte) { got <- msg })
+	}()
+	// Wait for the subscription before publishing
+	for {
+		m.mu.Lock()
+		n := len(m.subscribers)
+		m.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if seq, _ := m.PublishMeshDelta(ctx, []byte(`{"patch":[]}`)); seq != 1 {
+		t.Errorf("expected seq 1, got %d", seq)
+	}
+	if seq, _ := m.PublishMeshUpdate(ctx, []byte(`{"graph":{}}`), []byte(`{"patch":[]}`), time.Minute); seq != 2 {
+		t.Errorf("expected seq 2, got %d", seq)
+	}
+	for want := uint64(1); want <= 2; want++ {
+		var delta struct{ Seq uint64 }
+		if err := json.Unmarshal(<-got, &delta); err != nil || delta.Seq != want {
+			t.Errorf("expected delta %d, got %+v (%v)", want, delta, err)
+		}
+	}
+
+	snap, _ := m.GetMeshSnapshot(ctx)
+	if string(snap) != `{"seq":2,"graph":{}}` {
+		t.Errorf("unexpected snapshot %s", snap)
+	}
+	cancel()
+	<-done
+}
+
+func TestMemory_SnapshotExpires(t *testing.T) {
+	m := NewMemory()
+	ctx := context.Background()
+	if snap, _ := m.GetMeshSnapshot(ctx); snap != nil {
+		t.Fatalf("expected no snapshot, got %s", snap)
+	}
+	_, _ = m.PublishMeshUpdate(ctx, []byte(`{"graph":{}}`), []byte(`{"patch":[]}`), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if snap, _ := m.GetMeshSnapshot(ctx); snap != nil {
+		t.Errorf("expected the snapshot to expire, got %s", snap)
+	}
+}
+
+func TestMemory_Lease(t *testing.T) {
+	m := NewMemory()
+	ctx := context.Background()
+
+	if ok, _ := m.TryAcquireLeader(ctx, "a", time.Minute); !ok {
+		t.Fatalf("expected a to acquire")
+	}
+	if ok, _ := m.TryAcquireLeader(ctx, "b", time.Minute); ok {
+		t.Errorf("expected b to be refused while a holds the lease")
+	}
+	_ = m.ReleaseLeader(ctx, "b")
+	if ok, _ := m.RenewLeader(ctx, "a", time.Millisecond); !ok {
+		t.Errorf("expected a to renew")
+	}
+	time.Sleep(5 * time.Millisecond)
+	// An expired lease is free for anyone
+	if ok, _ := m.TryAcquireLeader(ctx, "b", time.Minute); !ok {
+		t.Errorf("expected b to take over the expired lease")
+	}
+	if ok, _ := m.RenewLeader(ctx, "a", time.Minute); ok {
+		t.Errorf("expected a's renew to fail")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	for url, want := range map[string]string{
+		"localhost:6379":           "*redisutil.RedisClient",
+		"redis://localhost:6379/1": "*redisutil.RedisClient",
+		"memory://":                "*backend.Memory",
+	} {
+		b, err := Open(url)
+		if err != nil {
+			t.Errorf("%s: %v", url, err)
+			continue
+		}
+		if got := fmt.Sprintf("%T", b); got != want {
+			t.Errorf("%s: expected %s, got %s", url, want, got)
+		}
+		_ = b.Close()
+	}
+	if _, err := Open("kafka://broker"); err == nil {
+		t.Errorf("expected an unsupported scheme to fail")
+	}
+}
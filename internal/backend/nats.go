@@ -0,0 +1,278 @@
+// internal/backend/nats.go
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	// deltaStream holds recent deltas; its stream sequence is the delta seq
+	deltaStream  = "MESH_DELTA"
+	deltaSubject = "mesh.delta"
+	// snapshotBucket and leaderBucket are JetStream key-value buckets whose
+	// TTL is set from the first snapshot or lease written
+	snapshotBucket = "mesh-snapshot"
+	snapshotKey    = "snapshot"
+	leaderBucket   = "mesh-leader"
+	leaderKey      = "leader"
+	// deltaHistory bounds the stream; subscribers only need the live tail
+	deltaHistory = 1024
+)
+
+// NATS is a Backend on NATS JetStream. Deltas go through a stream whose
+// sequence numbers order them; the snapshot and the lease live in key-value
+// buckets. Unlike Redis, the snapshot is written just after its delta, which
+// subscribers recovering from a gap tolerate by applying the delta on top.
+type NATS struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+
+	mu        sync.Mutex
+	stream    bool
+	snapshots jetstream.KeyValue
+	leases    jetstream.KeyValue
+}
+
+// NewNATS connects to the NATS server at url
+func NewNATS(url string) (*NATS, error) {
+	conn, err := nats.Connect(url, nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", url, err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &NATS{conn: conn, js: js}, nil
+}
+
+// ensureStream creates the delta stream on first use
+func (n *NATS) ensureStream(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.stream {
+		return nil
+	}
+	_, err := n.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     deltaStream,
+		Subjects: []string{deltaSubject},
+		MaxMsgs:  deltaHistory,
+	})
+	if err != nil {
+		return fmt.Errorf("creating stream %s: %w", deltaStream, err)
+	}
+	n.stream = true
+	return nil
+}
+
+// bucket opens the key-value bucket name, creating it with ttl when ttl is
+// set; it returns nil when the bucket does not exist and ttl is zero
+func (n *NATS) bucket(ctx context.Context, kv *jetstream.KeyValue, name string, ttl time.Duration) (jetstream.KeyValue, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if *kv != nil {
+		return *kv, nil
+	}
+	var err error
+	if ttl > 0 {
+		*kv, err = n.js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{Bucket: name, TTL: ttl, History: 1})
+	} else {
+		*kv, err = n.js.KeyValue(ctx, name)
+		if errors.Is(err, jetstream.ErrBucketNotFound) {
+			return nil, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening bucket %s: %w", name, err)
+	}
+	return *kv, nil
+}
+
+func (n *NATS) GetMeshSnapshot(ctx context.Context) ([]byte, error) {
+	kv, err := n.bucket(ctx, &n.snapshots, snapshotBucket, 0)
+	if err != nil || kv == nil {
+		return nil, err
+	}
+	entry, err := kv.Get(ctx, snapshotKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value(), nil
+}
+
+func (n *NATS) PublishMeshDelta(ctx context.Context, delta []byte) (uint64, error) {
+	if err := n.ensureStream(ctx); err != nil {
+		return 0, err
+	}
+	// Subscribers stamp the stream sequence into the payload
+	ack, err := n.js.Publish(ctx, deltaSubject, delta)
+	if err != nil {
+		return 0, err
+	}
+	return ack.Sequence, nil
+}
+
+func (n *NATS) PublishMeshUpdate(ctx context.Context, snapshot, delta []byte, ttl time.Duration) (uint64, error) {
+	seq, err := n.PublishMeshDelta(ctx, delta)
+	if err != nil {
+		return 0, err
+	}
+	kv, err := n.bucket(ctx, &n.snapshots, snapshotBucket, ttl)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := kv.Put(ctx, snapshotKey, stampSeq(snapshot, seq)); err != nil {
+		return 0, fmt.Errorf("storing snapshot %d: %w", seq, err)
+	}
+	return seq, nil
+}
+
+// RefreshMeshSnapshot rewrites the snapshot, as bucket TTLs count from the
+// last write. The graph has not changed since it was stored, so it is left
+// alone until half of ttl has passed.
+func (n *NATS) RefreshMeshSnapshot(ctx context.Context, ttl time.Duration) error {
+	kv, err := n.bucket(ctx, &n.snapshots, snapshotBucket, ttl)
+	if err != nil {
+		return err
+	}
+	entry, err := kv.Get(ctx, snapshotKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if time.Since(entry.Created()) < ttl/2 {
+		return nil
+	}
+	// Only if nothing newer was stored meanwhile
+	_, err = kv.Update(ctx, snapshotKey, entry.Value(), entry.Revision())
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return nil
+	}
+	return err
+}
+
+func (n *NATS) SubscribeMeshDelta(ctx context.Context, handler func([]byte)) error {
+	if err := n.ensureStream(ctx); err != nil {
+		return err
+	}
+	consumer, err := n.js.OrderedConsumer(ctx, deltaStream, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{deltaSubject},
+		DeliverPolicy:  jetstream.DeliverNewPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("subscribing to %s: %w", deltaSubject, err)
+	}
+	consuming, err := consumer.Consume(func(msg jetstream.Msg) {
+		meta, err := msg.Metadata()
+		if err != nil {
+			fmt.Printf("NATS: dropping delta without metadata: %v\n", err)
+			return
+		}
+		handler(stampSeq(msg.Data(), meta.Sequence.Stream))
+	})
+	if err != nil {
+		return fmt.Errorf("subscribing to %s: %w", deltaSubject, err)
+	}
+	<-ctx.Done()
+	consuming.Stop()
+	return ctx.Err()
+}
+
+func (n *NATS) NewElector(id string, ttl time.Duration, callbacks leader.Callbacks) leader.Elector {
+	return leader.NewLease(&natsLock{n: n}, leaderBucket, id, ttl, callbacks)
+}
+
+// Ping checks that the connection is up and JetStream answers
+func (n *NATS) Ping(ctx context.Context) error {
+	if status := n.conn.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("nats connection %s", status)
+	}
+	_, err := n.js.AccountInfo(ctx)
+	return err
+}
+
+func (n *NATS) Close() error {
+	n.conn.Close()
+	return nil
+}
+
+// natsLock is a leader.Lock on a key-value entry; the bucket TTL expires it
+// and every compare-and-set update renews it
+type natsLock struct {
+	n *NATS
+}
+
+func (l *natsLock) TryAcquireLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	kv, err := l.n.bucket(ctx, &l.n.leases, leaderBucket, ttl)
+	if err != nil {
+		return false, err
+	}
+	_, err = kv.Create(ctx, leaderKey, []byte(holder))
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, jetstream.ErrKeyExists) {
+		return false, err
+	}
+	// Held already; fine if by us
+	return l.RenewLeader(ctx, holder, ttl)
+}
+
+func (l *natsLock) RenewLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	kv, err := l.n.bucket(ctx, &l.n.leases, leaderBucket, ttl)
+	if err != nil {
+		return false, err
+	}
+	entry, err := kv.Get(ctx, leaderKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if string(entry.Value()) != holder {
+		return false, nil
+	}
+	_, err = kv.Update(ctx, leaderKey, []byte(holder), entry.Revision())
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (l *natsLock) ReleaseLeader(ctx context.Context, holder string) error {
+	kv, err := l.n.bucket(ctx, &l.n.leases, leaderBucket, 0)
+	if err != nil || kv == nil {
+		return err
+	}
+	entry, err := kv.Get(ctx, leaderKey)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if string(entry.Value()) != holder {
+		return nil
+	}
+	err = kv.Delete(ctx, leaderKey, jetstream.LastRevision(entry.Revision()))
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return nil
+	}
+	return err
+}
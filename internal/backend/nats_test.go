@@ -0,0 +1,193 @@
+// internal/backend/nats_test.go
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// newTestNATS connects to a fresh in-process JetStream server
+func newTestNATS(t *testing.T) *NATS {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      -1,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("nats-server did not start")
+	}
+	t.Cleanup(srv.Shutdown)
+	n, err := NewNATS(srv.ClientURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = n.Close() })
+	return n
+}
+
+func TestNATS_PublishAndSubscribe(t *testing.T) {
+	n := newTestNATS(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := make(chan []byte, 2)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = n.SubscribeMeshDelta(ctx, func(msg []byte) { got <- msg })
+	}()
+	// Wait for the consumer before publishing; it only gets new deltas
+	for {
+		if n.ensureStream(ctx) == nil {
+			stream, err := n.js.Stream(ctx, deltaStream)
+			if err == nil && stream.CachedInfo().State.Consumers == 1 {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Seqs are the stream's sequence numbers, stamped into each payload
+	if seq, err := n.PublishMeshDelta(ctx, []byte(`{"patch":[]}`)); err != nil || seq != 1 {
+		t.Errorf("expected seq 1, got %d (%v)", seq, err)
+	}
+	if seq, err := n.PublishMeshUpdate(ctx, []byte(`{"graph":{}}`), []byte(`{"patch":[]}`), time.Minute); err != nil || seq != 2 {
+		t.Errorf("expected seq 2, got %d (%v)", seq, err)
+	}
+	for want := uint64(1); want <= 2; want++ {
+		select {
+		case msg := <-got:
+			var delta struct{ Seq uint64 }
+			if err := json.Unmarshal(msg, &delta); err != nil || delta.Seq != want {
+				t.Errorf("expected delta %d, got %s (%v)", want, msg, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected delta %d", want)
+		}
+	}
+
+	snap, err := n.GetMeshSnapshot(ctx)
+	if err != nil || string(snap) != `{"seq":2,"graph":{}}` {
+		t.Errorf("unexpected snapshot %s (%v)", snap, err)
+	}
+	cancel()
+	<-done
+}
+
+func TestNATS_RefreshMeshSnapshot(t *testing.T) {
+	n := newTestNATS(t)
+	ctx := context.Background()
+	// Nothing to refresh before the first snapshot
+	if err := n.RefreshMeshSnapshot(ctx, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.PublishMeshUpdate(ctx, []byte(`{"graph":{}}`), []byte(`{"patch":[]}`), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	revision := func() uint64 {
+		t.Helper()
+		entry, err := n.snapshots.Get(ctx, snapshotKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entry.Revision()
+	}
+	stored := revision()
+
+	// A fresh snapshot is left alone
+	if err := n.RefreshMeshSnapshot(ctx, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got := revision(); got != stored {
+		t.Errorf("expected no rewrite before half the TTL, revision %d became %d", stored, got)
+	}
+
+	// Past half the TTL it is rewritten unchanged
+	time.Sleep(20 * time.Millisecond)
+	if err := n.RefreshMeshSnapshot(ctx, 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if got := revision(); got <= stored {
+		t.Errorf("expected a rewrite after half the TTL, revision stayed %d", got)
+	}
+	if snap, _ := n.GetMeshSnapshot(ctx); string(snap) != `{"seq":1,"graph":{}}` {
+		t.Errorf("expected the snapshot to be unchanged, got %s", snap)
+	}
+}
+
+func TestNATS_Lease(t *testing.T) {
+	n := newTestNATS(t)
+	ctx := context.Background()
+	lock := &natsLock{n: n}
+
+	// Of several candidates racing for a free lease, the compare-and-set
+	// lets exactly one win
+	var (
+		mu      sync.Mutex
+		winners []string
+		wg      sync.WaitGroup
+	)
+	for i := 0; i < 5; i++ {
+		holder := fmt.Sprintf("c-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := lock.TryAcquireLeader(ctx, holder, time.Minute)
+			if err != nil {
+				t.Error(err)
+			}
+			if ok {
+				mu.Lock()
+				winners = append(winners, holder)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(winners) != 1 {
+		t.Fatalf("expected one winner, got %v", winners)
+	}
+	a, b := winners[0], "other"
+
+	if ok, _ := lock.TryAcquireLeader(ctx, a, time.Minute); !ok {
+		t.Errorf("expected the holder to re-acquire")
+	}
+	if ok, _ := lock.TryAcquireLeader(ctx, b, time.Minute); ok {
+		t.Errorf("expected %s to be refused while %s holds the lease", b, a)
+	}
+	if ok, _ := lock.RenewLeader(ctx, b, time.Minute); ok {
+		t.Errorf("expected a renew by a non-holder to fail")
+	}
+	// Releasing a lease held by someone else does nothing
+	if err := lock.ReleaseLeader(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := lock.RenewLeader(ctx, a, time.Minute); !ok {
+		t.Errorf("expected %s to renew", a)
+	}
+
+	if err := lock.ReleaseLeader(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := lock.TryAcquireLeader(ctx, b, time.Minute); !ok {
+		t.Errorf("expected %s to acquire the released lease", b)
+	}
+	if ok, _ := lock.RenewLeader(ctx, a, time.Minute); ok {
+		t.Errorf("expected %s's renew to fail after losing the lease", a)
+	}
+}
@@ -0,0 +1,432 @@
+// internal/collector/collector.go
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/admin"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Config tunes a collector
+type Config struct {
+	PrometheusURL string
+	// Origin is stamped on published deltas: OriginPrefix, then host:pid
+	Origin string
+	// Publish makes the leader send graph changes to the backend. A server
+	// embedding the collector reads its store directly and leaves it off.
+	Publish bool
+	// SnapshotFormat is how published snapshots are encoded
+	SnapshotFormat graph.SnapshotFormat
+	// Checks, when set, gets an "informers" readiness check that passes once
+	// the informer caches have synced
+	Checks *admin.Checks
+}
+
+// OriginPrefix starts the origin of every collector, telling their deltas
+// apart from the requests servers publish
+const OriginPrefix = "collector@"
+
+// Run mirrors the cluster and Prometheus into store until ctx is done.
+// newElector builds the election with the given callbacks; while leading,
+// the collector publishes (if cfg.Publish) and reconciles requested policies
+// and routes arriving as deltas on mesh.
+func Run(ctx context.Context, cfg Config, store *graph.Store, mesh backend.Backend, clientset kubernetes.Interface, dynClient dynamic.Interface, newElector func(leader.Callbacks) (leader.Elector, error)) error {
+	registerMetrics()
+	// Requested policies and routes are applied to the cluster by a
+	// rate-limited controller
+	controller := newRequestController(store, dynClient)
+
+	// Only the lease holder publishes and reconciles; standbys keep their
+	// caches warm so they can take over immediately
+	lease, err := newElector(leader.Callbacks{
+		OnStartedLeading: func(ctx context.Context) {
+			leaderGauge.Set(1)
+			controller.enqueueAll()
+			if !cfg.Publish {
+				fmt.Println("Collector: elected leader")
+				return
+			}
+			fmt.Println("Collector: elected leader, publishing mesh graph")
+			publishLoop(ctx, cfg, mesh, store)
+		},
+		OnStoppedLeading: func() {
+			leaderGauge.Set(0)
+			fmt.Println("Collector: lost leadership, standing by")
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("setting up leader election: %w", err)
+	}
+	controller.isLeader = lease.IsLeader
+	if cfg.Publish {
+		controller.publish = func(ctx context.Context, patch graph.Patch) error {
+			delta, err := json.Marshal(graph.Delta{Revision: graph.Revision{Origin: cfg.Origin, Timestamp: time.Now().UTC()}, Patch: patch})
+			if err != nil {
+				return err
+			}
+			_, err = mesh.PublishMeshDelta(ctx, delta)
+			return err
+		}
+	}
+	// Initialize Prometheus client and poll metrics
+	promClient, err := api.NewClient(api.Config{Address: cfg.PrometheusURL})
+	if err != nil {
+		return fmt.Errorf("creating Prometheus client: %w", err)
+	}
+	v1api := promv1.NewAPI(promClient)
+
+	// Create informer factory
+	factory := informers.NewSharedInformerFactory(clientset, 0)
+
+	// Add informer for Service resources
+	serviceInformer := factory.Core().V1().Services().Informer()
+	serviceLister := factory.Core().V1().Services().Lister()
+	// Add informer for Pod resources
+	podInformer := factory.Core().V1().Pods().Informer()
+	podLister := factory.Core().V1().Pods().Lister()
+	// Add informers for workload controllers (ReplicaSets map pods to Deployments)
+	deploymentInformer := factory.Apps().V1().Deployments().Informer()
+	deploymentLister := factory.Apps().V1().Deployments().Lister()
+	statefulSetInformer := factory.Apps().V1().StatefulSets().Informer()
+	statefulSetLister := factory.Apps().V1().StatefulSets().Lister()
+	daemonSetInformer := factory.Apps().V1().DaemonSets().Informer()
+	daemonSetLister := factory.Apps().V1().DaemonSets().Lister()
+	replicaSetInformer := factory.Apps().V1().ReplicaSets().Informer()
+	replicaSetLister := factory.Apps().V1().ReplicaSets().Lister()
+
+	// Track how current each source is, for servers to report staleness
+	services := newInformerSource(graph.SourceServices)
+	services.add(serviceInformer)
+	pods := newInformerSource(graph.SourcePods)
+	pods.add(podInformer)
+	workloads := newInformerSource(graph.SourceWorkloads)
+	for _, informer := range []cache.SharedIndexInformer{deploymentInformer, statefulSetInformer, daemonSetInformer, replicaSetInformer} {
+		workloads.add(informer)
+	}
+	policies := newInformerSource(graph.SourcePolicies)
+
+	factory.Start(ctx.Done())
+
+	// upsertService recomputes mesh membership of svc from the pod cache
+	upsertService := func(svc *corev1.Service) {
+		pods, err := podLister.Pods(svc.Namespace).List(labels.Everything())
+		if err != nil {
+			fmt.Printf("Failed to list pods in %s: %v\n", svc.Namespace, err)
+			return
+		}
+		store.UpsertService(serviceNode(svc, pods))
+	}
+	listers := workloadListers{
+		deployments:  deploymentLister,
+		statefulSets: statefulSetLister,
+		daemonSets:   daemonSetLister,
+		replicaSets:  replicaSetLister,
+		pods:         podLister,
+		services:     serviceLister,
+	}
+	// refreshWorkloads rebuilds the workload nodes of namespace from the caches
+	refreshWorkloads := func(namespace string) {
+		objs, err := listers.namespace(namespace)
+		if err != nil {
+			fmt.Printf("Failed to list workloads in %s: %v\n", namespace, err)
+			return
+		}
+		store.ReplaceWorkloads(namespace, buildWorkloads(objs))
+	}
+	// refreshPod recomputes, after a pod change, the services selecting the
+	// pod before (oldPod, if any) or after it and the workload owning it
+	refreshPod := func(oldPod, pod *corev1.Pod) {
+		services, err := serviceLister.Services(pod.Namespace).List(labels.Everything())
+		if err != nil {
+			fmt.Printf("Failed to list services in %s: %v\n", pod.Namespace, err)
+			return
+		}
+		sets := []map[string]string{pod.Labels}
+		if oldPod != nil {
+			sets = append(sets, oldPod.Labels)
+		}
+		for _, svc := range servicesSelecting(services, sets...) {
+			upsertService(svc)
+		}
+		objs, ok, err := listers.owner(pod)
+		if err != nil {
+			fmt.Printf("Failed to list the workload of pod %s/%s: %v\n", pod.Namespace, pod.Name, err)
+			return
+		}
+		if ok {
+			for _, w := range buildWorkloads(objs) {
+				store.PutWorkload(w)
+			}
+		}
+	}
+
+	// Add event handlers to update mesh graph on Service add/update/delete
+	serviceInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				svc, ok := obj.(*corev1.Service)
+				if !ok {
+					fmt.Println("Service add: type assertion failed")
+					return
+				}
+				upsertService(svc)
+				refreshWorkloads(svc.Namespace)
+				fmt.Printf("Service added: %s/%s\n", svc.Namespace, svc.Name)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				svc, ok := newObj.(*corev1.Service)
+				if !ok {
+					fmt.Println("Service update: type assertion failed")
+					return
+				}
+				upsertService(svc)
+				refreshWorkloads(svc.Namespace)
+				fmt.Printf("Service updated: %s/%s\n", svc.Namespace, svc.Name)
+			},
+			DeleteFunc: func(obj interface{}) {
+				// Deletes missed during a watch gap arrive wrapped in a tombstone
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				svc, ok := obj.(*corev1.Service)
+				if !ok {
+					fmt.Println("Service delete: type assertion failed")
+					return
+				}
+				store.RemoveService(svc.Namespace, svc.Name)
+				refreshWorkloads(svc.Namespace)
+				fmt.Printf("Service deleted: %s/%s\n", svc.Namespace, svc.Name)
+			},
+		},
+	)
+
+	// Add event handlers to keep service membership in sync with Pod add/update/delete
+	podInformer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				pod, ok := obj.(*corev1.Pod)
+				if !ok {
+					fmt.Println("Pod add: type assertion failed")
+					return
+				}
+				refreshPod(nil, pod)
+				fmt.Printf("Pod added: %s/%s (meshed: %t)\n", pod.Namespace, pod.Name, podMeshed(pod))
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldPod, ok := oldObj.(*corev1.Pod)
+				if !ok {
+					fmt.Println("Pod update: type assertion failed")
+					return
+				}
+				pod, ok := newObj.(*corev1.Pod)
+				if !ok {
+					fmt.Println("Pod update: type assertion failed")
+					return
+				}
+				// Resyncs re-deliver unchanged objects
+				if oldPod.ResourceVersion == pod.ResourceVersion {
+					return
+				}
+				refreshPod(oldPod, pod)
+				fmt.Printf("Pod updated: %s/%s (meshed: %t)\n", pod.Namespace, pod.Name, podMeshed(pod))
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				pod, ok := obj.(*corev1.Pod)
+				if !ok {
+					fmt.Println("Pod delete: type assertion failed")
+					return
+				}
+				refreshPod(nil, pod)
+				fmt.Printf("Pod deleted: %s/%s\n", pod.Namespace, pod.Name)
+			},
+		},
+	)
+
+	// Rebuild workload nodes whenever a controller in their namespace changes
+	workloadHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if accessor, err := meta.Accessor(obj); err == nil {
+				refreshWorkloads(accessor.GetNamespace())
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if accessor, err := meta.Accessor(newObj); err == nil {
+				refreshWorkloads(accessor.GetNamespace())
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if accessor, err := meta.Accessor(obj); err == nil {
+				refreshWorkloads(accessor.GetNamespace())
+			}
+		},
+	}
+	for _, informer := range []cache.SharedIndexInformer{deploymentInformer, statefulSetInformer, daemonSetInformer, replicaSetInformer} {
+		informer.AddEventHandler(workloadHandler)
+	}
+
+	// Mirror Linkerd policy and Gateway API CRDs that are installed in the cluster
+	dynFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynClient, 0)
+	for _, res := range policyResources {
+		served, err := resourceServed(clientset.Discovery(), res.gvr)
+		if err != nil {
+			fmt.Printf("Skipping %s informer: %v\n", res.kind, err)
+			continue
+		}
+		if !served {
+			fmt.Printf("Skipping %s informer: %s not served\n", res.kind, res.gvr)
+			continue
+		}
+		kind := res.kind
+		upsert := func(obj interface{}) {
+			u, ok := obj.(*unstructured.Unstructured)
+			if !ok {
+				fmt.Printf("%s: type assertion failed\n", kind)
+				return
+			}
+			node := resourceFromObject(kind, u)
+			if _, ok := requestedKinds[strings.ToLower(kind)]; ok {
+				controller.observe(kind, u)
+			}
+			if kind == "AuthorizationPolicy" {
+				store.PutPolicy(graph.AuthPolicy{
+					Name:      node.Name,
+					Namespace: node.Namespace,
+					Spec:      node.Spec,
+					Managed:   node.Managed,
+				})
+			} else {
+				store.PutResource(node)
+			}
+			fmt.Printf("%s synced: %s/%s\n", kind, node.Namespace, node.Name)
+		}
+		generic := dynFactory.ForResource(res.gvr)
+		if _, ok := requestedKinds[strings.ToLower(kind)]; ok {
+			controller.listers[strings.ToLower(kind)] = generic.Lister()
+		}
+		informer := generic.Informer()
+		policies.add(informer)
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    upsert,
+			UpdateFunc: func(oldObj, newObj interface{}) { upsert(newObj) },
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				u, ok := obj.(*unstructured.Unstructured)
+				if !ok {
+					fmt.Printf("%s delete: type assertion failed\n", kind)
+					return
+				}
+				if kind == "AuthorizationPolicy" {
+					store.RemovePolicy(u.GetNamespace(), u.GetName())
+				} else {
+					store.RemoveResource(u.GetNamespace(), kind, u.GetName())
+				}
+				fmt.Printf("%s deleted: %s/%s\n", kind, u.GetNamespace(), u.GetName())
+				if _, ok := requestedKinds[strings.ToLower(kind)]; ok {
+					controller.observeDeletion(kind, u.GetNamespace(), u.GetName())
+				}
+			},
+		})
+	}
+	dynFactory.Start(ctx.Done())
+	sources := []*informerSource{services, pods, workloads, policies}
+	go trackFreshness(ctx, store, sources)
+	if cfg.Checks != nil {
+		cfg.Checks.Add("informers", func(ctx context.Context) error {
+			for _, src := range sources {
+				if err := src.synced(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	go controller.run(ctx, requestWorkers)
+
+	leaseDone := make(chan struct{})
+	go func() {
+		defer close(leaseDone)
+		lease.Run(ctx)
+	}()
+
+	// Subscribe to mesh:delta for policy reconciliation
+	go func() {
+		err := mesh.SubscribeMeshDelta(ctx, func(msg []byte) {
+			var delta graph.Delta
+			if err := json.Unmarshal(msg, &delta); err != nil {
+				fmt.Printf("Collector: failed to unmarshal mesh delta: %v\n", err)
+				return
+			}
+			// Collectors publish the mirror, not requests
+			if strings.HasPrefix(delta.Origin, OriginPrefix) {
+				return
+			}
+			// The store keeps mirroring the cluster; requests are kept
+			// apart until the cluster matches them
+			controller.requestPatch(delta.Patch)
+		})
+		if err != nil && ctx.Err() == nil {
+			fmt.Printf("Collector: error subscribing to mesh:delta: %v\n", err)
+		}
+	}()
+
+	// Poll Prometheus for the call edges
+	go func() {
+		var status graph.SourceStatus
+		for {
+			edges, err := queryEdges(ctx, v1api)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				fmt.Printf("Prometheus query error: %v\n", err)
+				// Keep the last edges; their age shows in LastSuccess
+				status.Synced = false
+				status.Error = err.Error()
+			} else {
+				store.ReplaceEdges(edges)
+				fmt.Printf("Updated mesh edges with %d edges\n", len(edges))
+				status = graph.SourceStatus{Synced: true, LastSuccess: time.Now().UTC()}
+			}
+			store.SetSource(graph.SourcePrometheus, status)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(15 * time.Second):
+			}
+		}
+	}()
+
+	<-ctx.Done()
+	// Hand leadership over without waiting for the lease to expire
+	<-leaseDone
+	return nil
+}
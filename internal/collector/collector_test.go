@@ -0,0 +1,53 @@
+// internal/collector/collector_test.go
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRun_FeedsStore(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+	})
+	dynClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	mesh := backend.NewMemory()
+	store := graph.NewStore()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- Run(ctx, Config{PrometheusURL: "http://127.0.0.1:1", Origin: "collector@test"}, store, mesh, clientset, dynClient,
+			func(callbacks leader.Callbacks) (leader.Elector, error) {
+				return mesh.NewElector("test", time.Second, callbacks), nil
+			})
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(store.Snapshot().Services) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the informer to add shop/web to the store")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, ok := store.Snapshot().Services[graph.ServiceKey("shop", "web")]; !ok {
+		t.Errorf("expected service shop/web, got %v", store.Snapshot().Services)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+// internal/collector/crds.go
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// policyResource is a Linkerd policy or Gateway API CRD mirrored into the graph
+type policyResource struct {
+	gvr  schema.GroupVersionResource
+	kind string
+}
+
+// policyResources lists the CRDs the collector watches. AuthorizationPolicies
+// land in MeshGraph.AuthPolicies, everything else in MeshGraph.Resources.
+var policyResources = []policyResource{
+	{gvr: schema.GroupVersionResource{Group: "policy.linkerd.io", Version: "v1beta3", Resource: "servers"}, kind: "Server"},
+	{gvr: schema.GroupVersionResource{Group: "policy.linkerd.io", Version: "v1beta1", Resource: "serverauthorizations"}, kind: "ServerAuthorization"},
+	{gvr: policy.AuthorizationPolicyGVR, kind: "AuthorizationPolicy"},
+	{gvr: schema.GroupVersionResource{Group: "policy.linkerd.io", Version: "v1alpha1", Resource: "meshtlsauthentications"}, kind: "MeshTLSAuthentication"},
+	{gvr: schema.GroupVersionResource{Group: "policy.linkerd.io", Version: "v1alpha1", Resource: "networkauthentications"}, kind: "NetworkAuthentication"},
+	{gvr: policy.HTTPRouteGVR, kind: "HTTPRoute"},
+	{gvr: policy.GRPCRouteGVR, kind: "GRPCRoute"},
+}
+
+// resourceServed reports whether the API server serves gvr, so informers are
+// only started for CRDs that are actually installed.
+func resourceServed(disc discovery.DiscoveryInterface, gvr schema.GroupVersionResource) (bool, error) {
+	list, err := disc.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		// A missing group/version is reported as NotFound
+		return false, fmt.Errorf("discovering %s: %w", gvr.GroupVersion(), err)
+	}
+	for _, r := range list.APIResources {
+		if r.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resourceFromObject converts a watched CRD into its graph node
+func resourceFromObject(kind string, obj *unstructured.Unstructured) graph.Resource {
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	return graph.Resource{
+		Kind:        kind,
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		Spec:        spec,
+		Annotations: policy.RouteAnnotations(obj.GetAnnotations()),
+		Managed:     policy.IsManaged(obj),
+	}
+}
@@ -0,0 +1,53 @@
+// internal/collector/crds_test.go
+
+package collector
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResourceServed(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "policy.linkerd.io/v1alpha1",
+		APIResources: []metav1.APIResource{{Name: "authorizationpolicies", Kind: "AuthorizationPolicy"}},
+	}}
+
+	for _, res := range policyResources {
+		served, err := resourceServed(client.Discovery(), res.gvr)
+		want := res.kind == "AuthorizationPolicy"
+		if res.gvr.GroupVersion().String() == "policy.linkerd.io/v1alpha1" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", res.kind, err)
+			}
+			if served != want {
+				t.Errorf("%s: expected served=%t, got %t", res.kind, want, served)
+			}
+		} else if err == nil || served {
+			t.Errorf("%s: expected missing group version to be reported, got served=%t err=%v", res.kind, served, err)
+		}
+	}
+}
+
+func TestResourceFromObject(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind":       "HTTPRoute",
+		"metadata":   map[string]interface{}{"namespace": "shop", "name": "web-route"},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{map[string]interface{}{"name": "web", "kind": "Service"}},
+		},
+	}}
+
+	node := resourceFromObject("HTTPRoute", obj)
+	if node.Kind != "HTTPRoute" || node.Namespace != "shop" || node.Name != "web-route" {
+		t.Errorf("unexpected resource identity: %+v", node)
+	}
+	if _, ok := node.Spec["parentRefs"]; !ok {
+		t.Errorf("expected spec to be copied, got %+v", node.Spec)
+	}
+}
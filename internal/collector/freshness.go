@@ -0,0 +1,101 @@
+// internal/collector/freshness.go
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// freshnessInterval is how often source freshness is recorded in the graph;
+// servers judge staleness against a multiple of it
+const freshnessInterval = 15 * time.Second
+
+// informerSource tracks the informers feeding one graph source: it is synced
+// while their caches are and no watch failed since the last check
+type informerSource struct {
+	name      string
+	informers []cache.SharedIndexInformer
+
+	mu  sync.Mutex
+	err error
+}
+
+func newInformerSource(name string) *informerSource {
+	return &informerSource{name: name}
+}
+
+// add tracks informer, which must not have been started yet
+func (s *informerSource) add(informer cache.SharedIndexInformer) {
+	s.informers = append(s.informers, informer)
+	err := informer.SetWatchErrorHandlerWithContext(func(ctx context.Context, r *cache.Reflector, err error) {
+		s.mu.Lock()
+		s.err = err
+		s.mu.Unlock()
+		cache.DefaultWatchErrorHandler(ctx, r, err)
+	})
+	if err != nil {
+		fmt.Printf("Freshness: cannot watch %s informer errors: %v\n", s.name, err)
+	}
+}
+
+// synced reports an error until every informer's cache has synced
+func (s *informerSource) synced() error {
+	for _, informer := range s.informers {
+		if !informer.HasSynced() {
+			return fmt.Errorf("%s informer cache not synced", s.name)
+		}
+	}
+	return nil
+}
+
+// check returns the source's status given its previous one
+func (s *informerSource) check(prev graph.SourceStatus, now time.Time) graph.SourceStatus {
+	s.mu.Lock()
+	err := s.err
+	s.err = nil
+	s.mu.Unlock()
+
+	status := graph.SourceStatus{Synced: true, LastSuccess: prev.LastSuccess}
+	for _, informer := range s.informers {
+		if !informer.HasSynced() {
+			status.Synced = false
+		}
+	}
+	switch {
+	case err != nil:
+		status.Synced = false
+		status.Error = err.Error()
+	case !status.Synced:
+		status.Error = "waiting for cache sync"
+	default:
+		status.LastSuccess = now
+	}
+	return status
+}
+
+// trackFreshness records the status of sources in store until ctx is done
+func trackFreshness(ctx context.Context, store *graph.Store, sources []*informerSource) {
+	ticker := time.NewTicker(freshnessInterval)
+	defer ticker.Stop()
+	prev := make(map[string]graph.SourceStatus)
+	for {
+		now := time.Now().UTC()
+		for _, src := range sources {
+			status := src.check(prev[src.name], now)
+			prev[src.name] = status
+			store.SetSource(src.name, status)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// internal/collector/freshness_test.go
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInformerSource_Check(t *testing.T) {
+	factory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	src := newInformerSource(graph.SourceServices)
+	src.add(factory.Core().V1().Services().Informer())
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// Not started, so never synced
+	status := src.check(graph.SourceStatus{}, now)
+	if status.Synced || !status.LastSuccess.IsZero() || status.Error == "" {
+		t.Errorf("expected an unsynced source, got %+v", status)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	status = src.check(status, now)
+	if !status.Synced || !status.LastSuccess.Equal(now) || status.Error != "" {
+		t.Errorf("expected a synced source confirmed now, got %+v", status)
+	}
+
+	// A failed watch marks it unsynced until the next check, keeping LastSuccess
+	src.err = errors.New("watch closed")
+	later := now.Add(freshnessInterval)
+	failed := src.check(status, later)
+	if failed.Synced || !failed.LastSuccess.Equal(now) || failed.Error != "watch closed" {
+		t.Errorf("expected the watch error to be reported, got %+v", failed)
+	}
+	if recovered := src.check(failed, later); !recovered.Synced || !recovered.LastSuccess.Equal(later) {
+		t.Errorf("expected the source to recover, got %+v", recovered)
+	}
+}
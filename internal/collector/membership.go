@@ -0,0 +1,83 @@
+// internal/collector/membership.go
+
+package collector
+
+import (
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	proxyContainerName     = "linkerd-proxy"
+	proxyVersionAnnotation = "linkerd.io/proxy-version"
+)
+
+// podMeshed reports whether a pod runs the Linkerd proxy, either as a regular
+// sidecar, as a native sidecar (restartable init container) or, for pods not
+// yet running, as announced by the injector's proxy-version annotation.
+func podMeshed(pod *corev1.Pod) bool {
+	if pod.Annotations[proxyVersionAnnotation] != "" {
+		return true
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name == proxyContainerName {
+			return true
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == proxyContainerName && c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceNode builds the graph node for svc from the pods its spec.selector
+// matches. pods may span namespaces; only those in the service's count.
+func serviceNode(svc *corev1.Service, pods []*corev1.Pod) graph.Service {
+	node := graph.Service{
+		Name:      svc.Name,
+		Namespace: svc.Namespace,
+	}
+	// Services without a selector (e.g. ExternalName, manual Endpoints) select no pods
+	if len(svc.Spec.Selector) == 0 {
+		return node
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	for _, pod := range pods {
+		if pod.Namespace != svc.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// Completed pods no longer back the service
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		node.TotalPods++
+		if podMeshed(pod) {
+			node.MeshedPods++
+		}
+	}
+	node.Meshed = node.TotalPods > 0 && node.MeshedPods == node.TotalPods
+	return node
+}
+
+// servicesSelecting returns the services whose selector matches any of the
+// label sets, e.g. a pod's labels before and after a change
+func servicesSelecting(services []*corev1.Service, sets ...map[string]string) []*corev1.Service {
+	var out []*corev1.Service
+	for _, svc := range services {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(svc.Spec.Selector)
+		for _, set := range sets {
+			if selector.Matches(labels.Set(set)) {
+				out = append(out, svc)
+				break
+			}
+		}
+	}
+	return out
+}
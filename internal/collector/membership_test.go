@@ -0,0 +1,90 @@
+// internal/collector/membership_test.go
+
+package collector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPod(namespace, name string, podLabels map[string]string, mutate func(*corev1.Pod)) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: podLabels},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if mutate != nil {
+		mutate(pod)
+	}
+	return pod
+}
+
+func TestServiceNode(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app.kubernetes.io/name": "web"}},
+	}
+	selected := map[string]string{"app.kubernetes.io/name": "web", "pod-template-hash": "abc"}
+
+	pods := []*corev1.Pod{
+		testPod("shop", "sidecar", selected, func(p *corev1.Pod) {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: "linkerd-proxy"})
+		}),
+		testPod("shop", "native-sidecar", selected, func(p *corev1.Pod) {
+			p.Spec.InitContainers = []corev1.Container{{Name: "linkerd-proxy", RestartPolicy: &always}}
+		}),
+		testPod("shop", "annotated", selected, func(p *corev1.Pod) {
+			p.Annotations = map[string]string{"linkerd.io/proxy-version": "edge-25.5.1"}
+		}),
+		testPod("shop", "plain", selected, nil),
+		testPod("shop", "done", selected, func(p *corev1.Pod) { p.Status.Phase = corev1.PodSucceeded }),
+		testPod("shop", "other-app", map[string]string{"app.kubernetes.io/name": "cart"}, nil),
+		testPod("staging", "other-ns", selected, nil),
+	}
+
+	node := serviceNode(svc, pods)
+	if node.TotalPods != 4 || node.MeshedPods != 3 {
+		t.Errorf("expected 3/4 meshed pods, got %d/%d", node.MeshedPods, node.TotalPods)
+	}
+	if node.Meshed {
+		t.Errorf("expected partially meshed service not to be reported as meshed")
+	}
+
+	node = serviceNode(svc, pods[:3])
+	if !node.Meshed {
+		t.Errorf("expected fully meshed service, got %+v", node)
+	}
+
+	svc.Spec.Selector = nil
+	if node := serviceNode(svc, pods); node.TotalPods != 0 || node.Meshed {
+		t.Errorf("expected selector-less service to select nothing, got %+v", node)
+	}
+}
+
+func TestServicesSelecting(t *testing.T) {
+	service := func(name string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name}, Spec: corev1.ServiceSpec{Selector: selector}}
+	}
+	services := []*corev1.Service{
+		service("web", map[string]string{"app": "web"}),
+		service("web-canary", map[string]string{"app": "web", "track": "canary"}),
+		service("db", map[string]string{"app": "db"}),
+		service("external", nil),
+	}
+
+	// A relabelled pod updates the services it left and joined
+	got := servicesSelecting(services, map[string]string{"app": "web", "track": "canary"}, map[string]string{"app": "db"})
+	var names []string
+	for _, svc := range got {
+		names = append(names, svc.Name)
+	}
+	if len(names) != 3 || names[0] != "web" || names[1] != "web-canary" || names[2] != "db" {
+		t.Errorf("expected web, web-canary and db, got %v", names)
+	}
+	if got := servicesSelecting(services, nil); len(got) != 0 {
+		t.Errorf("expected an unlabelled pod to match no service, got %d", len(got))
+	}
+}
@@ -0,0 +1,58 @@
+// internal/collector/metrics.go
+
+package collector
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	leaderGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "mcp_collector_leader",
+		Help: "1 while this collector holds the leader lease.",
+	})
+	promQueries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_collector_prometheus_queries_total",
+		Help: "Prometheus queries run, by query and result (ok or error).",
+	}, []string{"query", "result"})
+	promQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "mcp_collector_prometheus_query_duration_seconds",
+		Help: "Latency of Prometheus queries.",
+	}, []string{"query"})
+	deltasPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_collector_deltas_published_total",
+		Help: "Publish attempts of graph deltas, by result (ok or error).",
+	}, []string{"result"})
+	deltaOps = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "mcp_collector_delta_ops",
+		Help:    "JSON-Patch operations per published delta.",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	})
+	reconciles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_collector_policy_reconciles_total",
+		Help: "Reconciliations of requested policies and routes against the cluster, by kind and result (unchanged, applied, deleted, invalid or error).",
+	}, []string{"kind", "result"})
+)
+
+var registerOnce sync.Once
+
+// registerMetrics exposes the collector metrics on the default registry. It
+// runs when a collector starts rather than on import, so a server that
+// links this package without running one does not report them.
+func registerMetrics() {
+	registerOnce.Do(func() {
+		prometheus.MustRegister(leaderGauge, promQueries, promQueryDuration, deltasPublished, deltaOps, reconciles)
+	})
+}
+
+// observeQuery records the outcome of a Prometheus query named query
+func observeQuery(query string, seconds float64, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	promQueries.WithLabelValues(query, result).Inc()
+	promQueryDuration.WithLabelValues(query).Observe(seconds)
+}
@@ -0,0 +1,213 @@
+// internal/collector/prometheus.go
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// edgeLabels identify both ends of an edge on outbound proxy metrics: the
+// scrape labels (namespace, deployment/statefulset/daemonset) name the source
+// workload and the dst_* labels name the destination.
+const edgeLabels = `namespace, deployment, statefulset, daemonset, dst_namespace, dst_deployment, dst_service`
+
+// edgeQuery reads outbound traffic as seen by the calling proxy; tls tells
+// whether the connection was mTLS'd.
+const edgeQuery = `sum by(` + edgeLabels + `, tls)(rate(request_total{direction="outbound"}[30s]))`
+
+// successQuery splits responses by the proxy's success/failure classification
+const successQuery = `sum by(` + edgeLabels + `, classification)(rate(response_total{direction="outbound"}[30s]))`
+
+// latencyQuery is formatted with the quantile to compute
+const latencyQuery = `histogram_quantile(%g, sum by(le, ` + edgeLabels + `)(rate(response_latency_ms_bucket{direction="outbound"}[30s])))`
+
+// queryEdges runs the traffic query to build source-aware edges, then
+// enriches them with success rate and latency. Only a failed traffic query is
+// an error; missing golden signals leave those fields unset.
+func queryEdges(ctx context.Context, api promv1.API) ([]graph.Edge, error) {
+	vector, err := queryVector(ctx, api, "traffic", edgeQuery)
+	if err != nil {
+		return nil, err
+	}
+	edges := edgesFromVector(vector)
+
+	if vector, err := queryVector(ctx, api, "success_rate", successQuery); err != nil {
+		fmt.Printf("Prometheus success rate query error: %v\n", err)
+	} else {
+		applySuccessRates(edges, vector)
+	}
+	for _, q := range []float64{0.5, 0.95, 0.99} {
+		vector, err := queryVector(ctx, api, fmt.Sprintf("latency_p%g", q*100), fmt.Sprintf(latencyQuery, q))
+		if err != nil {
+			fmt.Printf("Prometheus p%g latency query error: %v\n", q*100, err)
+			continue
+		}
+		applyLatency(edges, q, vector)
+	}
+	return edges, nil
+}
+
+// queryVector runs query, recording it in the metrics under name
+func queryVector(ctx context.Context, api promv1.API, name, query string) (model.Vector, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	start := time.Now()
+	result, warnings, err := api.Query(ctx, query, start)
+	observeQuery(name, time.Since(start).Seconds(), err)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		fmt.Printf("Prometheus warnings: %v\n", warnings)
+	}
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Prometheus result type %s", result.Type())
+	}
+	return vector, nil
+}
+
+// edgeFromMetric reads the endpoints of an edge from a sample's labels. The
+// destination is the service when the proxy resolved one, otherwise the
+// destination workload.
+func edgeFromMetric(metric model.Metric) (graph.Edge, bool) {
+	src := firstLabel(metric, "deployment", "statefulset", "daemonset")
+	dst := firstLabel(metric, "dst_service", "dst_deployment")
+	if src == "" || dst == "" {
+		return graph.Edge{}, false
+	}
+	return graph.Edge{
+		SrcNamespace: string(metric["namespace"]),
+		Src:          src,
+		DstNamespace: string(metric["dst_namespace"]),
+		Dst:          dst,
+	}, true
+}
+
+// edgeTraffic accumulates the samples of one edge
+type edgeTraffic struct {
+	edge   graph.Edge
+	tlsRPS float64
+	// plainSeries lets idle edges (rate 0) still report their TLS status
+	plainSeries int
+}
+
+// edgesFromVector folds traffic samples into one edge per (source workload,
+// destination) pair. TLSRatio is the share of requests sent over mTLS; TLS is
+// only set when all of them were.
+func edgesFromVector(vector model.Vector) []graph.Edge {
+	byKey := make(map[string]*edgeTraffic)
+	for _, sample := range vector {
+		e, ok := edgeFromMetric(sample.Metric)
+		if !ok {
+			continue
+		}
+		key := graph.EdgeKey(e)
+		t, ok := byKey[key]
+		if !ok {
+			t = &edgeTraffic{edge: e}
+			byKey[key] = t
+		}
+		rps := float64(sample.Value)
+		t.edge.RPS += rps
+		if sample.Metric["tls"] == "true" {
+			t.tlsRPS += rps
+		} else {
+			t.plainSeries++
+		}
+	}
+
+	edges := make([]graph.Edge, 0, len(byKey))
+	for _, t := range byKey {
+		e := t.edge
+		switch {
+		case e.RPS > 0:
+			e.TLSRatio = t.tlsRPS / e.RPS
+		case t.plainSeries == 0:
+			e.TLSRatio = 1
+		}
+		e.TLS = e.TLSRatio == 1
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool { return graph.EdgeKey(edges[i]) < graph.EdgeKey(edges[j]) })
+	return edges
+}
+
+// applySuccessRates sets SuccessRate on edges from classified response rates
+func applySuccessRates(edges []graph.Edge, vector model.Vector) {
+	type counts struct{ success, total float64 }
+	byKey := make(map[string]*counts)
+	for _, sample := range vector {
+		e, ok := edgeFromMetric(sample.Metric)
+		if !ok {
+			continue
+		}
+		key := graph.EdgeKey(e)
+		c, ok := byKey[key]
+		if !ok {
+			c = &counts{}
+			byKey[key] = c
+		}
+		c.total += float64(sample.Value)
+		if sample.Metric["classification"] == "success" {
+			c.success += float64(sample.Value)
+		}
+	}
+	for i := range edges {
+		if c, ok := byKey[graph.EdgeKey(edges[i])]; ok && c.total > 0 {
+			edges[i].SuccessRate = c.success / c.total
+		}
+	}
+}
+
+// applyLatency sets the latency field for quantile q (0.5, 0.95 or 0.99).
+// Several series can fold into one edge (e.g. one per destination
+// deployment behind a service); quantiles cannot be averaged, so the edge
+// reports the highest, never understating its latency.
+func applyLatency(edges []graph.Edge, q float64, vector model.Vector) {
+	byKey := make(map[string]float64)
+	for _, sample := range vector {
+		e, ok := edgeFromMetric(sample.Metric)
+		v := float64(sample.Value)
+		// histogram_quantile yields NaN for idle series
+		if !ok || math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		key := graph.EdgeKey(e)
+		if prev, ok := byKey[key]; !ok || v > prev {
+			byKey[key] = v
+		}
+	}
+	for i := range edges {
+		v, ok := byKey[graph.EdgeKey(edges[i])]
+		if !ok {
+			continue
+		}
+		switch q {
+		case 0.5:
+			edges[i].LatencyP50Ms = v
+		case 0.95:
+			edges[i].LatencyP95Ms = v
+		case 0.99:
+			edges[i].LatencyP99Ms = v
+		}
+	}
+}
+
+func firstLabel(metric model.Metric, names ...model.LabelName) string {
+	for _, name := range names {
+		if v := metric[name]; v != "" {
+			return string(v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+// internal/collector/prometheus_test.go
+
+package collector
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestEdgesFromVector(t *testing.T) {
+	vector := model.Vector{
+		{Metric: model.Metric{"namespace": "default", "deployment": "service-a", "dst_namespace": "default", "dst_deployment": "service-b", "dst_service": "service-b", "tls": "true"}, Value: 2},
+		// Same pair reported under a second series is folded into one edge
+		{Metric: model.Metric{"namespace": "default", "deployment": "service-a", "dst_namespace": "default", "dst_service": "service-b", "tls": "no_identity"}, Value: 0.5},
+		{Metric: model.Metric{"namespace": "shop", "statefulset": "db-client", "dst_namespace": "shop", "dst_deployment": "db", "tls": "true"}, Value: 0},
+		// No source workload: not attributable, dropped
+		{Metric: model.Metric{"dst_namespace": "default", "dst_service": "service-b"}, Value: 3},
+	}
+
+	edges := edgesFromVector(vector)
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 edges, got %+v", edges)
+	}
+	if e := edges[0]; e.Src != "service-a" || e.Dst != "service-b" || e.RPS != 2.5 || e.SrcNamespace != "default" {
+		t.Errorf("unexpected edge: %+v", e)
+	}
+	if e := edges[0]; e.TLS || e.TLSRatio != 0.8 {
+		t.Errorf("expected partially mTLS'd edge with ratio 0.8, got %+v", e)
+	}
+	if e := edges[1]; e.Src != "db-client" || e.Dst != "db" || e.DstNamespace != "shop" || !e.TLS {
+		t.Errorf("unexpected edge: %+v", e)
+	}
+}
+
+func TestGoldenSignals(t *testing.T) {
+	edges := edgesFromVector(model.Vector{
+		{Metric: model.Metric{"namespace": "shop", "deployment": "checkout", "dst_namespace": "shop", "dst_service": "payments", "tls": "true"}, Value: 10},
+	})
+
+	applySuccessRates(edges, model.Vector{
+		{Metric: model.Metric{"namespace": "shop", "deployment": "checkout", "dst_namespace": "shop", "dst_service": "payments", "classification": "success"}, Value: 9},
+		{Metric: model.Metric{"namespace": "shop", "deployment": "checkout", "dst_namespace": "shop", "dst_service": "payments", "classification": "failure"}, Value: 1},
+	})
+	// Series for each deployment behind the service fold into one edge
+	applyLatency(edges, 0.99, model.Vector{
+		{Metric: model.Metric{"namespace": "shop", "deployment": "checkout", "dst_namespace": "shop", "dst_service": "payments", "dst_deployment": "payments-v2"}, Value: 400},
+		{Metric: model.Metric{"namespace": "shop", "deployment": "checkout", "dst_namespace": "shop", "dst_service": "payments", "dst_deployment": "payments-v1"}, Value: 250},
+	})
+	applyLatency(edges, 0.5, model.Vector{
+		{Metric: model.Metric{"namespace": "shop", "deployment": "checkout", "dst_namespace": "shop", "dst_service": "payments"}, Value: model.SampleValue(math.NaN())},
+	})
+
+	e := edges[0]
+	if e.SuccessRate != 0.9 {
+		t.Errorf("expected success rate 0.9, got %v", e.SuccessRate)
+	}
+	if e.LatencyP99Ms != 400 || e.LatencyP50Ms != 0 {
+		t.Errorf("expected the highest p99 (400) and NaN p50 ignored, got %+v", e)
+	}
+}
@@ -0,0 +1,76 @@
+// internal/collector/publish.go
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/backend"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+const (
+	// snapshotTTL bounds how long a snapshot outlives a collector that stopped publishing
+	snapshotTTL = 10 * time.Minute
+	// publishInterval is how often graph changes are batched into a delta
+	publishInterval = 5 * time.Second
+)
+
+// publishLoop publishes graph changes as sequenced JSON-Patch deltas, storing
+// the matching snapshot with each so subscribers can recover from gaps. It
+// runs while this collector leads, until ctx is cancelled.
+func publishLoop(ctx context.Context, cfg Config, mesh backend.Backend, store *graph.Store) {
+	ticker := time.NewTicker(publishInterval)
+	defer ticker.Stop()
+	// The first publish replaces whatever an earlier leader left behind
+	var published *graph.MeshGraph
+	for {
+		current := store.Snapshot()
+		seq, ops, err := publishGraph(ctx, cfg, mesh, published, current)
+		if err != nil && ctx.Err() == nil {
+			deltasPublished.WithLabelValues("error").Inc()
+			fmt.Printf("Failed to publish mesh delta: %v\n", err)
+		} else if err == nil && ops > 0 {
+			published = current
+			deltasPublished.WithLabelValues("ok").Inc()
+			deltaOps.Observe(float64(ops))
+			fmt.Printf("Published mesh delta %d (%d ops)\n", seq, ops)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishGraph sends the changes from published to current as one sequenced
+// delta, storing the matching snapshot under the same sequence number. It
+// returns that number and the count of patch operations (0 when unchanged).
+func publishGraph(ctx context.Context, cfg Config, mesh backend.Backend, published, current *graph.MeshGraph) (uint64, int, error) {
+	patch, err := graph.CreatePatch(published, current)
+	if err != nil {
+		return 0, 0, fmt.Errorf("diffing mesh graph: %w", err)
+	}
+	if len(patch) == 0 {
+		return 0, 0, mesh.RefreshMeshSnapshot(ctx, snapshotTTL)
+	}
+	// Seq is left for the backend to assign
+	rev := graph.Revision{Origin: cfg.Origin, Timestamp: time.Now().UTC()}
+	snapshot, err := graph.EncodeSnapshot(&graph.Snapshot{Revision: rev, Graph: current}, cfg.SnapshotFormat)
+	if err != nil {
+		return 0, 0, fmt.Errorf("encoding mesh snapshot: %w", err)
+	}
+	delta, err := json.Marshal(graph.Delta{Revision: rev, Patch: patch})
+	if err != nil {
+		return 0, 0, fmt.Errorf("marshaling mesh delta: %w", err)
+	}
+	seq, err := mesh.PublishMeshUpdate(ctx, snapshot, delta, snapshotTTL)
+	if err != nil {
+		return 0, 0, err
+	}
+	return seq, len(patch), nil
+}
@@ -0,0 +1,411 @@
+// internal/collector/reconcile.go
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// requestWorkers is how many requested objects are reconciled in parallel
+const requestWorkers = 2
+
+// requestedKind is a kind that can be requested through the API
+type requestedKind struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}
+
+// requestedKinds are keyed by their lowercase name, as in graph.ResourceKey
+var requestedKinds = map[string]requestedKind{
+	"authorizationpolicy": {kind: "AuthorizationPolicy", gvr: policy.AuthorizationPolicyGVR},
+	"httproute":           {kind: "HTTPRoute", gvr: policy.HTTPRouteGVR},
+	"grpcroute":           {kind: "GRPCRoute", gvr: policy.GRPCRouteGVR},
+}
+
+// requestController makes the cluster's AuthorizationPolicies and routes
+// match the ones requested through the API, reverting edits to them and
+// recreating them when deleted, and prunes managed ones whose removal was
+// requested. Requests are kept apart from the graph, which mirrors the
+// cluster: they arrive as deltas from servers, or are taken from managed
+// objects the informers see before any request (e.g. after a restart) or
+// that a server applied directly. Hand-written objects are never acted on
+// unless a request adopts them. Objects are keyed by graph.ResourceKey,
+// queued as requests and informer events arrive and retried with
+// exponential backoff; only the leader acts on them.
+type requestController struct {
+	store *graph.Store
+	dyn   dynamic.Interface
+	queue workqueue.TypedRateLimitingInterface[string]
+
+	mu sync.Mutex
+	// requests holds the desired object for each requested key, or nil
+	// when its removal was requested
+	requests map[string]*unstructured.Unstructured
+
+	// listers read the informers' view of the cluster, keyed like
+	// requestedKinds; a kind whose CRD is not served has none, and its
+	// queued objects are always applied
+	listers map[string]cache.GenericLister
+	// isLeader gates reconciliation to the lease holder
+	isLeader func() bool
+	// publish, when set, tells servers about a request the API server
+	// rejected, so they drop it from their graphs too
+	publish func(ctx context.Context, patch graph.Patch) error
+}
+
+func newRequestController(store *graph.Store, dyn dynamic.Interface) *requestController {
+	return &requestController{
+		store: store,
+		dyn:   dyn,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "requests"},
+		),
+		requests: make(map[string]*unstructured.Unstructured),
+		listers:  make(map[string]cache.GenericLister),
+		isLeader: func() bool { return true },
+	}
+}
+
+// splitKey splits a resource key into its namespace, lowercase kind and name
+func splitKey(key string) (namespace, kind, name string, ok bool) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// requestedKey returns the key of the requested object a delta op's path
+// touches, if it touches one
+func requestedKey(path string) (string, bool) {
+	section, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	// The graph key is one JSON Pointer token, so its "/" is escaped as "~1"
+	token, _, _ := strings.Cut(rest, "/")
+	key := strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	switch section {
+	case "AuthPolicies":
+		namespace, name, ok := strings.Cut(key, "/")
+		if !ok || namespace == "" || name == "" {
+			return "", false
+		}
+		return graph.ResourceKey(namespace, "AuthorizationPolicy", name), true
+	case "Resources":
+		_, kind, _, ok := splitKey(key)
+		if _, requested := requestedKinds[kind]; !ok || !requested {
+			return "", false
+		}
+		return key, true
+	}
+	return "", false
+}
+
+// enqueue queues the object with key (namespace/kind/name) for reconciliation
+func (c *requestController) enqueue(key string) {
+	c.queue.Add(key)
+}
+
+// request records what was requested for key and queues it; a nil obj
+// requests its removal
+func (c *requestController) request(key string, obj *unstructured.Unstructured) {
+	c.mu.Lock()
+	c.requests[key] = obj
+	c.mu.Unlock()
+	c.enqueue(key)
+}
+
+// requested returns what was requested for key, and whether anything was
+func (c *requestController) requested(key string) (*unstructured.Unstructured, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	obj, ok := c.requests[key]
+	return obj, ok
+}
+
+// forget drops the request for key unless a newer one replaced obj
+func (c *requestController) forget(key string, obj *unstructured.Unstructured) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if current, ok := c.requests[key]; ok && current == obj {
+		delete(c.requests, key)
+	}
+}
+
+// requestPatch records the requests in a server's delta. Servers add,
+// replace and remove whole entries; anything else is not a request.
+func (c *requestController) requestPatch(patch graph.Patch) {
+	for _, op := range patch {
+		key, ok := requestedKey(op.Path)
+		if !ok || strings.Count(op.Path, "/") != 2 {
+			continue
+		}
+		if op.Op == "remove" {
+			c.request(key, nil)
+			continue
+		}
+		obj, err := requestedObject(key, op.Value)
+		if err != nil {
+			fmt.Printf("Collector: ignoring request for %s: %v\n", key, err)
+			continue
+		}
+		if obj != nil {
+			c.request(key, obj)
+		}
+	}
+}
+
+// requestedObject decodes the graph node a delta op carries for key into the
+// object to apply, or nil when the node was not requested through the API
+func requestedObject(key string, value json.RawMessage) (*unstructured.Unstructured, error) {
+	namespace, kindName, name, _ := splitKey(key)
+	kind := requestedKinds[kindName].kind
+	if kind == "AuthorizationPolicy" {
+		var p graph.AuthPolicy
+		if err := json.Unmarshal(value, &p); err != nil {
+			return nil, err
+		}
+		if !p.Managed {
+			return nil, nil
+		}
+		return policy.AuthorizationPolicy(namespace, name, p.Spec), nil
+	}
+	var res graph.Resource
+	if err := json.Unmarshal(value, &res); err != nil {
+		return nil, err
+	}
+	if !res.Managed {
+		return nil, nil
+	}
+	return policy.Route(kind, namespace, name, res.Spec, res.Annotations), nil
+}
+
+// observe handles an informer event for a requestable object that exists
+// in the cluster. A managed object becomes the request when there is none
+// yet or when this project wrote it last (a server applying directly);
+// anything else differing from the request is drift to revert.
+func (c *requestController) observe(kind string, obj *unstructured.Unstructured) {
+	key := graph.ResourceKey(obj.GetNamespace(), kind, obj.GetName())
+	c.mu.Lock()
+	current, ok := c.requests[key]
+	if policy.IsManaged(obj) && (!ok || (current != nil && policy.LastWrittenByUs(obj))) {
+		desired := policy.AuthorizationPolicy(obj.GetNamespace(), obj.GetName(), nil)
+		if kind != "AuthorizationPolicy" {
+			desired = policy.Route(kind, obj.GetNamespace(), obj.GetName(), nil, policy.RouteAnnotations(obj.GetAnnotations()))
+		}
+		spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+		desired.Object["spec"] = spec
+		c.requests[key] = desired
+		ok = true
+	}
+	c.mu.Unlock()
+	if ok {
+		c.enqueue(key)
+	}
+}
+
+// observeDeletion handles an informer event for a requestable object
+// deleted from the cluster, which is recreated if still requested
+func (c *requestController) observeDeletion(kind, namespace, name string) {
+	key := graph.ResourceKey(namespace, kind, name)
+	if _, ok := c.requested(key); ok {
+		c.enqueue(key)
+	}
+}
+
+// enqueueAll queues every request, e.g. on becoming leader
+func (c *requestController) enqueueAll() {
+	c.mu.Lock()
+	keys := make([]string, 0, len(c.requests))
+	for key := range c.requests {
+		keys = append(keys, key)
+	}
+	c.mu.Unlock()
+	for _, key := range keys {
+		c.enqueue(key)
+	}
+}
+
+// run processes the queue with workers until ctx is done
+func (c *requestController) run(ctx context.Context, workers int) {
+	defer c.queue.ShutDown()
+	for i := 0; i < workers; i++ {
+		go func() {
+			for c.processNext(ctx) {
+			}
+		}()
+	}
+	<-ctx.Done()
+}
+
+// processNext reconciles one key, returning false once the queue shut down
+func (c *requestController) processNext(ctx context.Context) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if !c.isLeader() {
+		// The next leader re-queues every object when elected
+		c.queue.Forget(key)
+		return true
+	}
+	result, err := c.reconcile(ctx, key)
+	_, kind, _, _ := splitKey(key)
+	reconciles.WithLabelValues(requestedKinds[kind].kind, result).Inc()
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+	if result == "invalid" {
+		// Retrying cannot fix the request itself
+		fmt.Printf("Reconcile %s: giving up: %v\n", key, err)
+		c.queue.Forget(key)
+		return true
+	}
+	fmt.Printf("Reconcile %s failed (attempt %d, retrying with backoff): %v\n", key, c.queue.NumRequeues(key)+1, err)
+	c.queue.AddRateLimited(key)
+	return true
+}
+
+// reconcile applies the object requested for key when the cluster differs,
+// or prunes it when its removal was requested, returning the outcome:
+// "unchanged", "applied", "deleted", "invalid" or "error"
+func (c *requestController) reconcile(ctx context.Context, key string) (string, error) {
+	namespace, kindName, name, ok := splitKey(key)
+	kind, requested := requestedKinds[kindName]
+	if !ok || !requested {
+		return "invalid", fmt.Errorf("invalid key %q", key)
+	}
+	desired, ok := c.requested(key)
+	if !ok {
+		return "unchanged", nil
+	}
+	if desired == nil {
+		result, err := c.prune(ctx, kind, namespace, name)
+		if err == nil {
+			c.forget(key, nil)
+		}
+		return result, err
+	}
+	exists := false
+	if lister := c.listers[kindName]; lister != nil {
+		obj, err := lister.ByNamespace(namespace).Get(name)
+		switch {
+		case err == nil:
+			exists = true
+			if u, ok := obj.(*unstructured.Unstructured); ok && inSync(u, desired) {
+				return "unchanged", nil
+			}
+		case !apierrors.IsNotFound(err):
+			return "error", err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	// The request wins over other managers and hand edits
+	result, err := policy.Apply(ctx, c.dyn, kind.gvr, desired, true)
+	if err != nil {
+		return "error", err
+	}
+	if len(result.Errors) > 0 {
+		c.reject(ctx, key, desired, exists)
+		return "invalid", fmt.Errorf("rejected by the API server: %s", result.Errors[0].Message)
+	}
+	fmt.Printf("Applied %s %s/%s (resourceVersion %s)\n", kind.kind, namespace, name, result.ResourceVersion)
+	return "applied", nil
+}
+
+// reject drops a request the API server refused. Servers put requests in
+// their graphs when making them, so unless the object exists in the
+// cluster (and so belongs in the graph) it is removed from the store, which
+// servers share in embedded mode, and from theirs through publish.
+func (c *requestController) reject(ctx context.Context, key string, desired *unstructured.Unstructured, exists bool) {
+	c.forget(key, desired)
+	if exists {
+		return
+	}
+	namespace, kindName, name, _ := splitKey(key)
+	if kindName == "authorizationpolicy" {
+		c.store.RemovePolicy(namespace, name)
+	} else {
+		c.store.RemoveResource(namespace, requestedKinds[kindName].kind, name)
+	}
+	if c.publish == nil {
+		return
+	}
+	before := &graph.MeshGraph{}
+	if kindName == "authorizationpolicy" {
+		before.AuthPolicies = map[string]graph.AuthPolicy{graph.PolicyKey(namespace, name): {Namespace: namespace, Name: name}}
+	} else {
+		before.Resources = map[string]graph.Resource{key: {Kind: requestedKinds[kindName].kind, Namespace: namespace, Name: name}}
+	}
+	patch, err := graph.CreatePatch(before, &graph.MeshGraph{})
+	if err == nil {
+		err = c.publish(ctx, patch)
+	}
+	if err != nil {
+		fmt.Printf("Reconcile %s: failed to publish the rejection: %v\n", key, err)
+	}
+}
+
+// inSync reports whether the observed object has the desired spec and
+// Linkerd annotations, and is labelled as managed
+func inSync(observed, desired *unstructured.Unstructured) bool {
+	spec, _, _ := unstructured.NestedMap(observed.Object, "spec")
+	want, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	return policy.IsManaged(observed) &&
+		equality.Semantic.DeepEqual(spec, want) &&
+		equality.Semantic.DeepEqual(policy.RouteAnnotations(observed.GetAnnotations()), policy.RouteAnnotations(desired.GetAnnotations()))
+}
+
+// prune deletes the object namespace/name from the cluster if this project
+// created it; hand-written objects are never deleted
+func (c *requestController) prune(ctx context.Context, kind requestedKind, namespace, name string) (string, error) {
+	if lister := c.listers[strings.ToLower(kind.kind)]; lister != nil {
+		obj, err := lister.ByNamespace(namespace).Get(name)
+		if apierrors.IsNotFound(err) {
+			return "unchanged", nil
+		}
+		if err != nil {
+			return "error", err
+		}
+		if accessor, err := meta.Accessor(obj); err == nil && !policy.IsManaged(accessor) {
+			return "unchanged", nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	deleted, err := policy.Delete(ctx, c.dyn, kind.gvr, namespace, name)
+	if errors.Is(err, policy.ErrNotManaged) {
+		return "unchanged", nil
+	}
+	if err != nil {
+		return "error", err
+	}
+	if !deleted {
+		return "unchanged", nil
+	}
+	fmt.Printf("Pruned %s %s/%s\n", kind.kind, namespace, name)
+	return "deleted", nil
+}
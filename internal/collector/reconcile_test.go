@@ -0,0 +1,366 @@
+// internal/collector/reconcile_test.go
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/policy"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// writtenBy records manager as the last writer of obj at the given time
+func writtenBy(obj *unstructured.Unstructured, manager string, at time.Time) {
+	obj.SetManagedFields(append(obj.GetManagedFields(), metav1.ManagedFieldsEntry{
+		Manager:   manager,
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		Time:      &metav1.Time{Time: at},
+	}))
+}
+
+func TestRequestController(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	applies := 0
+	var applyErr error
+	dyn.PrependReactor("patch", "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		applies++
+		if applyErr != nil {
+			return true, nil, applyErr
+		}
+		return true, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+	})
+
+	store := graph.NewStore()
+	spec := map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "web-http"}}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c := newRequestController(store, dyn)
+	c.listers["authorizationpolicy"] = cache.NewGenericLister(indexer, policy.AuthorizationPolicyGVR.GroupResource())
+	defer c.queue.ShutDown()
+
+	// Keys are decoded from the delta's JSON Pointer paths
+	patch, err := graph.CreatePatch(&graph.MeshGraph{}, &graph.MeshGraph{AuthPolicies: map[string]graph.AuthPolicy{
+		graph.PolicyKey("shop", "web"): {Name: "web", Namespace: "shop", Spec: spec, Managed: true},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.requestPatch(patch)
+	if c.queue.Len() != 1 {
+		t.Fatalf("expected shop/web to be queued once, got %d keys", c.queue.Len())
+	}
+	// The store mirrors the cluster; requests are kept apart
+	if len(store.Policies()) != 0 {
+		t.Errorf("expected the request to stay out of the store, got %v", store.Policies())
+	}
+
+	// Transient failures are retried with backoff
+	applyErr = errors.New("connection refused")
+	c.processNext(context.Background())
+	if applies != 1 || c.queue.NumRequeues("shop/authorizationpolicy/web") != 1 {
+		t.Errorf("expected a failed apply to be requeued, applies=%d requeues=%d", applies, c.queue.NumRequeues("shop/authorizationpolicy/web"))
+	}
+
+	applyErr = nil
+	c.processNext(context.Background())
+	if applies != 2 || c.queue.NumRequeues("shop/authorizationpolicy/web") != 0 {
+		t.Errorf("expected the retry to apply and reset the backoff, applies=%d", applies)
+	}
+
+	// Once the informer observes the requested spec there is nothing to do
+	obj := policy.AuthorizationPolicy("shop", "web", spec)
+	if err := indexer.Add(obj); err != nil {
+		t.Fatal(err)
+	}
+	c.enqueueAll()
+	c.processNext(context.Background())
+	if applies != 2 {
+		t.Errorf("expected no apply for an unchanged policy, applies=%d", applies)
+	}
+
+	// Standbys drop keys; the next leader re-queues everything
+	c.isLeader = func() bool { return false }
+	indexer.Delete(obj)
+	c.enqueue("shop/authorizationpolicy/web")
+	c.processNext(context.Background())
+	if applies != 2 || c.queue.Len() != 0 {
+		t.Errorf("expected a standby not to apply, applies=%d", applies)
+	}
+}
+
+func TestRequestControllerRevertsDrift(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	var applied []map[string]interface{}
+	dyn.PrependReactor("patch", "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(action.(k8stesting.PatchActionImpl).GetPatch(), &obj); err != nil {
+			t.Fatal(err)
+		}
+		applied = append(applied, obj)
+		return true, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+	})
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c := newRequestController(graph.NewStore(), dyn)
+	c.listers["authorizationpolicy"] = cache.NewGenericLister(indexer, policy.AuthorizationPolicyGVR.GroupResource())
+	defer c.queue.ShutDown()
+	reconcile := func(obj *unstructured.Unstructured) string {
+		t.Helper()
+		if err := indexer.Update(obj); err != nil {
+			t.Fatal(err)
+		}
+		c.observe("AuthorizationPolicy", obj)
+		result, err := c.reconcile(context.Background(), "shop/authorizationpolicy/web")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	// A managed policy seen before any request is what was requested, e.g.
+	// after a restart
+	requested := map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "web-http"}}
+	start := time.Now()
+	obj := policy.AuthorizationPolicy("shop", "web", requested)
+	writtenBy(obj, policy.FieldManager, start)
+	if result := reconcile(obj); result != "unchanged" {
+		t.Errorf("expected a seeded policy to be unchanged, got %q", result)
+	}
+
+	// A hand edit is reverted
+	edited := obj.DeepCopy()
+	unstructured.SetNestedField(edited.Object, "admin-http", "spec", "targetRef", "name")
+	writtenBy(edited, "kubectl-edit", start.Add(time.Minute))
+	if result := reconcile(edited); result != "applied" {
+		t.Fatalf("expected a hand edit to be reverted, got %q", result)
+	}
+	if spec, _, _ := unstructured.NestedMap(applied[0], "spec"); !equality.Semantic.DeepEqual(spec, requested) {
+		t.Errorf("expected the requested spec to be applied, got %v", spec)
+	}
+
+	// A server applying directly changes the request
+	direct := edited.DeepCopy()
+	unstructured.SetNestedField(direct.Object, "web-https", "spec", "targetRef", "name")
+	writtenBy(direct, policy.FieldManager, start.Add(2*time.Minute))
+	if result := reconcile(direct); result != "unchanged" {
+		t.Errorf("expected a direct apply to become the request, got %q", result)
+	}
+
+	// A deleted policy is recreated from the request
+	if err := indexer.Delete(direct); err != nil {
+		t.Fatal(err)
+	}
+	c.observeDeletion("AuthorizationPolicy", "shop", "web")
+	if c.queue.Len() != 1 {
+		t.Errorf("expected the deletion to queue shop/web, got %d keys", c.queue.Len())
+	}
+	if result, err := c.reconcile(context.Background(), "shop/authorizationpolicy/web"); err != nil || result != "applied" {
+		t.Fatalf("expected a deleted policy to be recreated, got %q, %v", result, err)
+	}
+	if name, _, _ := unstructured.NestedString(applied[1], "spec", "targetRef", "name"); name != "web-https" {
+		t.Errorf("expected the latest request to be recreated, got %v", applied[1])
+	}
+}
+
+func TestRequestControllerRejected(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dyn.PrependReactor("patch", "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewInvalid(policy.AuthorizationPolicyGVR.GroupVersion().WithKind("AuthorizationPolicy").GroupKind(), "web",
+			field.ErrorList{field.Required(field.NewPath("spec", "targetRef"), "")})
+	})
+	store := graph.NewStore()
+	// Servers put what they request in their graph straight away
+	store.PutPolicy(graph.AuthPolicy{Name: "web", Namespace: "shop", Managed: true})
+	c := newRequestController(store, dyn)
+	var published graph.Patch
+	c.publish = func(ctx context.Context, patch graph.Patch) error {
+		published = patch
+		return nil
+	}
+	defer c.queue.ShutDown()
+
+	c.request("shop/authorizationpolicy/web", policy.AuthorizationPolicy("shop", "web", nil))
+	c.processNext(context.Background())
+	if c.queue.Len() != 0 || c.queue.NumRequeues("shop/authorizationpolicy/web") != 0 {
+		t.Errorf("expected a rejected request not to be retried")
+	}
+	if _, ok := c.requested("shop/authorizationpolicy/web"); ok {
+		t.Errorf("expected a rejected request to be dropped")
+	}
+	if _, ok := store.Policies()[graph.PolicyKey("shop", "web")]; ok {
+		t.Errorf("expected the rejected policy to leave the graph")
+	}
+	if len(published) != 1 || published[0].Op != "remove" {
+		t.Errorf("expected the removal to be published, got %+v", published)
+	}
+}
+
+func TestRequestControllerPrune(t *testing.T) {
+	spec := map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "web-http"}}
+	managed := policy.AuthorizationPolicy("shop", "web", spec)
+	handWritten := policy.AuthorizationPolicy("shop", "admin", spec)
+	handWritten.SetLabels(nil)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), managed, handWritten)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range []*unstructured.Unstructured{managed, handWritten} {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := newRequestController(graph.NewStore(), dyn)
+	c.listers["authorizationpolicy"] = cache.NewGenericLister(indexer, policy.AuthorizationPolicyGVR.GroupResource())
+	defer c.queue.ShutDown()
+
+	// Both removals are requested, but only the managed policy is pruned
+	patch, err := graph.CreatePatch(&graph.MeshGraph{AuthPolicies: map[string]graph.AuthPolicy{
+		graph.PolicyKey("shop", "web"):   {Name: "web", Namespace: "shop"},
+		graph.PolicyKey("shop", "admin"): {Name: "admin", Namespace: "shop"},
+	}}, &graph.MeshGraph{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.requestPatch(patch)
+	for _, key := range []string{"shop/authorizationpolicy/web", "shop/authorizationpolicy/admin"} {
+		result, err := c.reconcile(context.Background(), key)
+		if err != nil {
+			t.Fatalf("reconcile %s: %v", key, err)
+		}
+		if want := map[string]string{"shop/authorizationpolicy/web": "deleted", "shop/authorizationpolicy/admin": "unchanged"}[key]; result != want {
+			t.Errorf("reconcile %s: expected %q, got %q", key, want, result)
+		}
+		if _, ok := c.requested(key); ok {
+			t.Errorf("expected the removal of %s to be done with", key)
+		}
+	}
+	client := dyn.Resource(policy.AuthorizationPolicyGVR).Namespace("shop")
+	if _, err := client.Get(context.Background(), "web", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected shop/web to be deleted, got %v", err)
+	}
+	if _, err := client.Get(context.Background(), "admin", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the hand-written shop/admin to remain: %v", err)
+	}
+}
+
+func TestRequestControllerRoutes(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	var applied []map[string]interface{}
+	dyn.PrependReactor("patch", "httproutes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(action.(k8stesting.PatchActionImpl).GetPatch(), &obj); err != nil {
+			t.Fatal(err)
+		}
+		applied = append(applied, obj)
+		return true, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+	})
+
+	spec := map[string]interface{}{"parentRefs": []interface{}{map[string]interface{}{"kind": "Service", "name": "web"}}}
+	route := graph.Resource{Kind: "HTTPRoute", Namespace: "shop", Name: "web", Spec: spec, Annotations: map[string]string{"retry.linkerd.io/http": "5xx"}, Managed: true}
+	server := graph.Resource{Kind: "Server", Namespace: "shop", Name: "web-http"}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c := newRequestController(graph.NewStore(), dyn)
+	c.listers["httproute"] = cache.NewGenericLister(indexer, policy.HTTPRouteGVR.GroupResource())
+	defer c.queue.ShutDown()
+
+	// Only requestable kinds are queued
+	patch, err := graph.CreatePatch(&graph.MeshGraph{}, &graph.MeshGraph{Resources: map[string]graph.Resource{
+		graph.ResourceKey("shop", "HTTPRoute", "web"):   route,
+		graph.ResourceKey("shop", "Server", "web-http"): server,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.requestPatch(patch)
+	if c.queue.Len() != 1 {
+		t.Fatalf("expected only shop/httproute/web to be queued, got %d keys", c.queue.Len())
+	}
+	c.processNext(context.Background())
+	if len(applied) != 1 || applied[0]["kind"] != "HTTPRoute" {
+		t.Fatalf("expected the route to be applied, got %+v", applied)
+	}
+	annotations, _, _ := unstructured.NestedStringMap(applied[0], "metadata", "annotations")
+	if annotations["retry.linkerd.io/http"] != "5xx" {
+		t.Errorf("expected the retry annotation to be applied, got %v", annotations)
+	}
+
+	// A drifted annotation is re-applied; a matching route is left alone
+	observed := policy.Route("HTTPRoute", "shop", "web", spec, map[string]string{"retry.linkerd.io/http": "503"})
+	if err := indexer.Add(observed); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := c.reconcile(context.Background(), "shop/httproute/web"); err != nil || result != "applied" {
+		t.Errorf("expected a drifted route to be applied, got %q, %v", result, err)
+	}
+	observed.SetAnnotations(route.Annotations)
+	if err := indexer.Update(observed); err != nil {
+		t.Fatal(err)
+	}
+	if result, err := c.reconcile(context.Background(), "shop/httproute/web"); err != nil || result != "unchanged" {
+		t.Errorf("expected a matching route to be unchanged, got %q, %v", result, err)
+	}
+}
+
+func TestRequestControllerIgnoresUnmanaged(t *testing.T) {
+	edited := map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "admin-https"}}
+	handWritten := policy.AuthorizationPolicy("shop", "admin", edited)
+	handWritten.SetLabels(nil)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), handWritten)
+	writes := 0
+	for _, verb := range []string{"patch", "delete"} {
+		dyn.PrependReactor(verb, "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			writes++
+			return false, nil, nil
+		})
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(handWritten); err != nil {
+		t.Fatal(err)
+	}
+	c := newRequestController(graph.NewStore(), dyn)
+	c.listers["authorizationpolicy"] = cache.NewGenericLister(indexer, policy.AuthorizationPolicyGVR.GroupResource())
+	defer c.queue.ShutDown()
+
+	// A hand-written policy is never taken as a request
+	c.observe("AuthorizationPolicy", handWritten)
+	c.enqueueAll()
+	if c.queue.Len() != 0 {
+		t.Errorf("expected no unmanaged policy to be queued, got %d keys", c.queue.Len())
+	}
+	if result, err := c.reconcile(context.Background(), "shop/authorizationpolicy/admin"); err != nil || result != "unchanged" {
+		t.Errorf("expected an unmanaged policy to be left alone, got %q, %v", result, err)
+	}
+
+	// Nor is it pruned when its removal is requested
+	c.request("shop/authorizationpolicy/admin", nil)
+	if result, err := c.reconcile(context.Background(), "shop/authorizationpolicy/admin"); err != nil || result != "unchanged" {
+		t.Errorf("expected an unmanaged policy not to be pruned, got %q, %v", result, err)
+	}
+
+	if writes != 0 {
+		t.Errorf("expected no writes to the cluster, got %d", writes)
+	}
+	obj, err := dyn.Resource(policy.AuthorizationPolicyGVR).Namespace("shop").Get(context.Background(), "admin", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the hand-written policy to remain: %v", err)
+	}
+	if policy.IsManaged(obj) {
+		t.Errorf("expected the hand-written policy not to be relabelled")
+	}
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	if !equality.Semantic.DeepEqual(spec, edited) {
+		t.Errorf("expected the user's edit to survive, got %v", spec)
+	}
+}
@@ -0,0 +1,229 @@
+// internal/collector/workloads.go
+
+package collector
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// namespaceObjects is the informer cache content of one namespace
+type namespaceObjects struct {
+	deployments  []*appsv1.Deployment
+	statefulSets []*appsv1.StatefulSet
+	daemonSets   []*appsv1.DaemonSet
+	replicaSets  []*appsv1.ReplicaSet
+	pods         []*corev1.Pod
+	services     []*corev1.Service
+}
+
+// workloadListers read the informer caches workload nodes are built from
+type workloadListers struct {
+	deployments  appslisters.DeploymentLister
+	statefulSets appslisters.StatefulSetLister
+	daemonSets   appslisters.DaemonSetLister
+	replicaSets  appslisters.ReplicaSetLister
+	pods         corelisters.PodLister
+	services     corelisters.ServiceLister
+}
+
+// namespace lists the cache content of namespace
+func (l workloadListers) namespace(namespace string) (namespaceObjects, error) {
+	var objs namespaceObjects
+	var errs []error
+	var err error
+	objs.deployments, err = l.deployments.Deployments(namespace).List(labels.Everything())
+	errs = append(errs, err)
+	objs.statefulSets, err = l.statefulSets.StatefulSets(namespace).List(labels.Everything())
+	errs = append(errs, err)
+	objs.daemonSets, err = l.daemonSets.DaemonSets(namespace).List(labels.Everything())
+	errs = append(errs, err)
+	objs.replicaSets, err = l.replicaSets.ReplicaSets(namespace).List(labels.Everything())
+	errs = append(errs, err)
+	objs.pods, err = l.pods.Pods(namespace).List(labels.Everything())
+	errs = append(errs, err)
+	objs.services, err = l.services.Services(namespace).List(labels.Everything())
+	errs = append(errs, err)
+	return objs, utilerrors.NewAggregate(errs)
+}
+
+// owner lists the cache content that the node of the workload owning pod
+// is built from: the workload, the pods its selector matches, and the
+// namespace's ReplicaSets and services. ok is false when pod belongs to no
+// workload in the caches.
+func (l workloadListers) owner(pod *corev1.Pod) (objs namespaceObjects, ok bool, err error) {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return objs, false, nil
+	}
+	var selector *metav1.LabelSelector
+	switch ref.Kind {
+	case "ReplicaSet":
+		rs, err := l.replicaSets.ReplicaSets(pod.Namespace).Get(ref.Name)
+		if err != nil {
+			return objs, false, ignoreNotFound(err)
+		}
+		owner := metav1.GetControllerOf(rs)
+		if owner == nil || owner.Kind != "Deployment" {
+			return objs, false, nil
+		}
+		d, err := l.deployments.Deployments(pod.Namespace).Get(owner.Name)
+		if err != nil {
+			return objs, false, ignoreNotFound(err)
+		}
+		objs.deployments, selector = []*appsv1.Deployment{d}, d.Spec.Selector
+	case "StatefulSet":
+		sts, err := l.statefulSets.StatefulSets(pod.Namespace).Get(ref.Name)
+		if err != nil {
+			return objs, false, ignoreNotFound(err)
+		}
+		objs.statefulSets, selector = []*appsv1.StatefulSet{sts}, sts.Spec.Selector
+	case "DaemonSet":
+		ds, err := l.daemonSets.DaemonSets(pod.Namespace).Get(ref.Name)
+		if err != nil {
+			return objs, false, ignoreNotFound(err)
+		}
+		objs.daemonSets, selector = []*appsv1.DaemonSet{ds}, ds.Spec.Selector
+	default:
+		return objs, false, nil
+	}
+
+	podSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return objs, false, err
+	}
+	var errs []error
+	objs.pods, err = l.pods.Pods(pod.Namespace).List(podSelector)
+	errs = append(errs, err)
+	objs.replicaSets, err = l.replicaSets.ReplicaSets(pod.Namespace).List(labels.Everything())
+	errs = append(errs, err)
+	objs.services, err = l.services.Services(pod.Namespace).List(labels.Everything())
+	errs = append(errs, err)
+	return objs, true, utilerrors.NewAggregate(errs)
+}
+
+// ignoreNotFound drops the error of a lookup that found nothing
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// buildWorkloads assembles the workload nodes of a namespace, keyed by
+// graph.WorkloadKey. Pods are attributed to their workload through owner
+// references (via the ReplicaSet for Deployments) and services are linked
+// when their selector matches the workload's pod template.
+func buildWorkloads(objs namespaceObjects) map[string]graph.Workload {
+	workloads := make(map[string]graph.Workload)
+	templates := make(map[string]map[string]string)
+
+	add := func(kind string, meta metav1.ObjectMeta, replicas, ready int, template map[string]string) {
+		w := graph.Workload{
+			Kind:      kind,
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+			Replicas:  replicas,
+			ReadyPods: ready,
+		}
+		if ref := metav1.GetControllerOf(&meta); ref != nil {
+			w.Owner = ref.Kind + "/" + ref.Name
+		}
+		key := graph.WorkloadKey(meta.Namespace, kind, meta.Name)
+		workloads[key] = w
+		templates[key] = template
+	}
+	for _, d := range objs.deployments {
+		add("Deployment", d.ObjectMeta, int(replicasOrOne(d.Spec.Replicas)), int(d.Status.ReadyReplicas), d.Spec.Template.Labels)
+	}
+	for _, s := range objs.statefulSets {
+		add("StatefulSet", s.ObjectMeta, int(replicasOrOne(s.Spec.Replicas)), int(s.Status.ReadyReplicas), s.Spec.Template.Labels)
+	}
+	for _, d := range objs.daemonSets {
+		add("DaemonSet", d.ObjectMeta, int(d.Status.DesiredNumberScheduled), int(d.Status.NumberReady), d.Spec.Template.Labels)
+	}
+
+	// ReplicaSet name -> owning Deployment name
+	rsOwners := make(map[string]string)
+	for _, rs := range objs.replicaSets {
+		if ref := metav1.GetControllerOf(rs); ref != nil && ref.Kind == "Deployment" {
+			rsOwners[rs.Name] = ref.Name
+		}
+	}
+
+	proxyVersions := make(map[string]map[string]bool)
+	for _, pod := range objs.pods {
+		key := podWorkloadKey(pod, rsOwners)
+		w, ok := workloads[key]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if podMeshed(pod) {
+			w.MeshedPods++
+		}
+		if v := pod.Annotations[proxyVersionAnnotation]; v != "" {
+			if proxyVersions[key] == nil {
+				proxyVersions[key] = make(map[string]bool)
+			}
+			proxyVersions[key][v] = true
+		}
+		workloads[key] = w
+	}
+
+	for key, w := range workloads {
+		// Mid-rollout a workload can run several proxy versions
+		var versions []string
+		for v := range proxyVersions[key] {
+			versions = append(versions, v)
+		}
+		sort.Strings(versions)
+		w.ProxyVersion = strings.Join(versions, ",")
+
+		for _, svc := range objs.services {
+			if len(svc.Spec.Selector) == 0 {
+				continue
+			}
+			if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(templates[key])) {
+				w.Services = append(w.Services, graph.ServiceKey(svc.Namespace, svc.Name))
+			}
+		}
+		sort.Strings(w.Services)
+		workloads[key] = w
+	}
+	return workloads
+}
+
+// podWorkloadKey resolves the workload a pod belongs to, or "" if none
+func podWorkloadKey(pod *corev1.Pod, rsOwners map[string]string) string {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return ""
+	}
+	switch ref.Kind {
+	case "ReplicaSet":
+		if deployment, ok := rsOwners[ref.Name]; ok {
+			return graph.WorkloadKey(pod.Namespace, "Deployment", deployment)
+		}
+	case "StatefulSet", "DaemonSet":
+		return graph.WorkloadKey(pod.Namespace, ref.Kind, ref.Name)
+	}
+	return ""
+}
+
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
@@ -0,0 +1,142 @@
+// internal/collector/workloads_test.go
+
+package collector
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func TestBuildWorkloads(t *testing.T) {
+	replicas := int32(2)
+	webLabels := map[string]string{"app": "web"}
+	objs := namespaceObjects{
+		deployments: []*appsv1.Deployment{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web", OwnerReferences: controllerRef("Rollout", "web")},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: webLabels}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+		}},
+		statefulSets: []*appsv1.StatefulSet{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "db"},
+			Spec:       appsv1.StatefulSetSpec{Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "db"}}}},
+		}},
+		replicaSets: []*appsv1.ReplicaSet{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-abc", OwnerReferences: controllerRef("Deployment", "web")},
+		}},
+		pods: []*corev1.Pod{
+			testPod("shop", "web-abc-1", webLabels, func(p *corev1.Pod) {
+				p.OwnerReferences = controllerRef("ReplicaSet", "web-abc")
+				p.Annotations = map[string]string{"linkerd.io/proxy-version": "edge-25.5.1"}
+			}),
+			testPod("shop", "web-abc-2", webLabels, func(p *corev1.Pod) {
+				p.OwnerReferences = controllerRef("ReplicaSet", "web-abc")
+				p.Annotations = map[string]string{"linkerd.io/proxy-version": "edge-25.4.4"}
+			}),
+			testPod("shop", "db-0", map[string]string{"app": "db"}, func(p *corev1.Pod) {
+				p.OwnerReferences = controllerRef("StatefulSet", "db")
+			}),
+		},
+		services: []*corev1.Service{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+			Spec:       corev1.ServiceSpec{Selector: webLabels},
+		}},
+	}
+
+	workloads := buildWorkloads(objs)
+	web, ok := workloads["shop/deployment/web"]
+	if !ok {
+		t.Fatalf("expected web deployment, got %+v", workloads)
+	}
+	if web.Replicas != 2 || web.ReadyPods != 1 || web.MeshedPods != 2 {
+		t.Errorf("unexpected web counts: %+v", web)
+	}
+	if web.ProxyVersion != "edge-25.4.4,edge-25.5.1" || web.Owner != "Rollout/web" {
+		t.Errorf("unexpected web proxy/owner: %+v", web)
+	}
+	if len(web.Services) != 1 || web.Services[0] != "shop/web" {
+		t.Errorf("expected web to be linked to shop/web, got %v", web.Services)
+	}
+
+	db := workloads["shop/statefulset/db"]
+	if db.Replicas != 1 || db.MeshedPods != 0 || len(db.Services) != 0 {
+		t.Errorf("unexpected db workload: %+v", db)
+	}
+}
+
+// indexed returns an informer-style index holding objs
+func indexed(t *testing.T, objs ...interface{}) cache.Indexer {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return indexer
+}
+
+func TestWorkloadListersOwner(t *testing.T) {
+	webLabels := map[string]string{"app": "web"}
+	web := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: webLabels},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: webLabels}},
+		},
+	}
+	// Mid-rollout the deployment's pods span two ReplicaSets
+	oldRS := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-old", OwnerReferences: controllerRef("Deployment", "web")}}
+	newRS := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-new", OwnerReferences: controllerRef("Deployment", "web")}}
+	meshed := func(p *corev1.Pod) { p.Annotations = map[string]string{"linkerd.io/proxy-version": "edge-25.5.1"} }
+	oldPod := testPod("shop", "web-old-1", webLabels, func(p *corev1.Pod) { p.OwnerReferences = controllerRef("ReplicaSet", "web-old") })
+	newPod := testPod("shop", "web-new-1", webLabels, func(p *corev1.Pod) {
+		p.OwnerReferences = controllerRef("ReplicaSet", "web-new")
+		meshed(p)
+	})
+	dbPod := testPod("shop", "db-0", map[string]string{"app": "db"}, func(p *corev1.Pod) { p.OwnerReferences = controllerRef("StatefulSet", "db") })
+	barePod := testPod("shop", "debug", nil, nil)
+	l := workloadListers{
+		deployments:  appslisters.NewDeploymentLister(indexed(t, web)),
+		statefulSets: appslisters.NewStatefulSetLister(indexed(t)),
+		daemonSets:   appslisters.NewDaemonSetLister(indexed(t)),
+		replicaSets:  appslisters.NewReplicaSetLister(indexed(t, oldRS, newRS)),
+		pods:         corelisters.NewPodLister(indexed(t, oldPod, newPod, dbPod, barePod)),
+		services:     corelisters.NewServiceLister(indexed(t)),
+	}
+
+	objs, ok, err := l.owner(newPod)
+	if err != nil || !ok {
+		t.Fatalf("expected the owner of %s, got %v, %v", newPod.Name, ok, err)
+	}
+	workloads := buildWorkloads(objs)
+	if len(workloads) != 1 {
+		t.Fatalf("expected only the owning workload, got %+v", workloads)
+	}
+	if w := workloads["shop/deployment/web"]; w.MeshedPods != 1 || w.ProxyVersion != "edge-25.5.1" {
+		t.Errorf("expected the pods of both ReplicaSets to be counted, got %+v", w)
+	}
+	if len(objs.pods) != 2 {
+		t.Errorf("expected only the deployment's pods to be listed, got %d", len(objs.pods))
+	}
+
+	// Pods of workloads missing from the caches, or of none, have no owner
+	for _, pod := range []*corev1.Pod{dbPod, barePod} {
+		if _, ok, err := l.owner(pod); ok || err != nil {
+			t.Errorf("expected no owner for %s, got %v, %v", pod.Name, ok, err)
+		}
+	}
+}
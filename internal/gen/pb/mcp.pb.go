@@ -11,6 +11,8 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,28 +25,1504 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Placeholder messages
+type MeshGraphEvent_Type int32
+
+const (
+	MeshGraphEvent_TYPE_UNSPECIFIED MeshGraphEvent_Type = 0
+	MeshGraphEvent_SNAPSHOT         MeshGraphEvent_Type = 1
+	MeshGraphEvent_SERVICE_ADDED    MeshGraphEvent_Type = 2
+	MeshGraphEvent_SERVICE_UPDATED  MeshGraphEvent_Type = 3
+	MeshGraphEvent_SERVICE_REMOVED  MeshGraphEvent_Type = 4
+	MeshGraphEvent_EDGE_ADDED       MeshGraphEvent_Type = 5
+	MeshGraphEvent_EDGE_UPDATED     MeshGraphEvent_Type = 6
+	MeshGraphEvent_EDGE_REMOVED     MeshGraphEvent_Type = 7
+	MeshGraphEvent_POLICY_APPLIED   MeshGraphEvent_Type = 8
+	MeshGraphEvent_POLICY_REMOVED   MeshGraphEvent_Type = 9
+	MeshGraphEvent_WORKLOAD_ADDED   MeshGraphEvent_Type = 10
+	MeshGraphEvent_WORKLOAD_UPDATED MeshGraphEvent_Type = 11
+	MeshGraphEvent_WORKLOAD_REMOVED MeshGraphEvent_Type = 12
+	MeshGraphEvent_RESOURCE_APPLIED MeshGraphEvent_Type = 13
+	MeshGraphEvent_RESOURCE_REMOVED MeshGraphEvent_Type = 14
+)
+
+// Enum value maps for MeshGraphEvent_Type.
+var (
+	MeshGraphEvent_Type_name = map[int32]string{
+		0:  "TYPE_UNSPECIFIED",
+		1:  "SNAPSHOT",
+		2:  "SERVICE_ADDED",
+		3:  "SERVICE_UPDATED",
+		4:  "SERVICE_REMOVED",
+		5:  "EDGE_ADDED",
+		6:  "EDGE_UPDATED",
+		7:  "EDGE_REMOVED",
+		8:  "POLICY_APPLIED",
+		9:  "POLICY_REMOVED",
+		10: "WORKLOAD_ADDED",
+		11: "WORKLOAD_UPDATED",
+		12: "WORKLOAD_REMOVED",
+		13: "RESOURCE_APPLIED",
+		14: "RESOURCE_REMOVED",
+	}
+	MeshGraphEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"SNAPSHOT":         1,
+		"SERVICE_ADDED":    2,
+		"SERVICE_UPDATED":  3,
+		"SERVICE_REMOVED":  4,
+		"EDGE_ADDED":       5,
+		"EDGE_UPDATED":     6,
+		"EDGE_REMOVED":     7,
+		"POLICY_APPLIED":   8,
+		"POLICY_REMOVED":   9,
+		"WORKLOAD_ADDED":   10,
+		"WORKLOAD_UPDATED": 11,
+		"WORKLOAD_REMOVED": 12,
+		"RESOURCE_APPLIED": 13,
+		"RESOURCE_REMOVED": 14,
+	}
+)
+
+func (x MeshGraphEvent_Type) Enum() *MeshGraphEvent_Type {
+	p := new(MeshGraphEvent_Type)
+	*p = x
+	return p
+}
+
+func (x MeshGraphEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MeshGraphEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_mcp_proto_enumTypes[0].Descriptor()
+}
+
+func (MeshGraphEvent_Type) Type() protoreflect.EnumType {
+	return &file_mcp_proto_enumTypes[0]
+}
+
+func (x MeshGraphEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MeshGraphEvent_Type.Descriptor instead.
+func (MeshGraphEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{16, 0}
+}
+
+// Mesh graph model (mirrors internal/graph)
+type Service struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// True when every pod selected by the service runs the Linkerd proxy
+	Meshed bool `protobuf:"varint,3,opt,name=meshed,proto3" json:"meshed,omitempty"`
+	// Pods matched by the service's spec.selector, and how many are meshed
+	MeshedPods    int32 `protobuf:"varint,4,opt,name=meshed_pods,json=meshedPods,proto3" json:"meshed_pods,omitempty"`
+	TotalPods     int32 `protobuf:"varint,5,opt,name=total_pods,json=totalPods,proto3" json:"total_pods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_mcp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Service) GetMeshed() bool {
+	if x != nil {
+		return x.Meshed
+	}
+	return false
+}
+
+func (x *Service) GetMeshedPods() int32 {
+	if x != nil {
+		return x.MeshedPods
+	}
+	return 0
+}
+
+func (x *Service) GetTotalPods() int32 {
+	if x != nil {
+		return x.TotalPods
+	}
+	return 0
+}
+
+// Deployment, StatefulSet or DaemonSet
+type Workload struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Kind      string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Desired pod count and how many are ready
+	Replicas   int32 `protobuf:"varint,4,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	ReadyPods  int32 `protobuf:"varint,5,opt,name=ready_pods,json=readyPods,proto3" json:"ready_pods,omitempty"`
+	MeshedPods int32 `protobuf:"varint,6,opt,name=meshed_pods,json=meshedPods,proto3" json:"meshed_pods,omitempty"`
+	// Proxy versions injected in the workload's pods, comma-separated
+	ProxyVersion string `protobuf:"bytes,7,opt,name=proxy_version,json=proxyVersion,proto3" json:"proxy_version,omitempty"`
+	// Controller owning the workload itself ("Kind/name"), if any
+	Owner string `protobuf:"bytes,8,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Keys (namespace/name) of services selecting the workload's pods
+	Services      []string `protobuf:"bytes,9,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workload) Reset() {
+	*x = Workload{}
+	mi := &file_mcp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workload) ProtoMessage() {}
+
+func (x *Workload) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workload.ProtoReflect.Descriptor instead.
+func (*Workload) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Workload) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Workload) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workload) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Workload) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *Workload) GetReadyPods() int32 {
+	if x != nil {
+		return x.ReadyPods
+	}
+	return 0
+}
+
+func (x *Workload) GetMeshedPods() int32 {
+	if x != nil {
+		return x.MeshedPods
+	}
+	return 0
+}
+
+func (x *Workload) GetProxyVersion() string {
+	if x != nil {
+		return x.ProxyVersion
+	}
+	return ""
+}
+
+func (x *Workload) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Workload) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type Edge struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source workload (deployment/statefulset/daemonset) name
+	Src string `protobuf:"bytes,1,opt,name=src,proto3" json:"src,omitempty"`
+	// Destination service, or workload when no service was resolved
+	Dst string  `protobuf:"bytes,2,opt,name=dst,proto3" json:"dst,omitempty"`
+	Rps float64 `protobuf:"fixed64,3,opt,name=rps,proto3" json:"rps,omitempty"`
+	// True when all observed requests were mTLS'd
+	Tls          bool   `protobuf:"varint,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	SrcNamespace string `protobuf:"bytes,5,opt,name=src_namespace,json=srcNamespace,proto3" json:"src_namespace,omitempty"`
+	DstNamespace string `protobuf:"bytes,6,opt,name=dst_namespace,json=dstNamespace,proto3" json:"dst_namespace,omitempty"`
+	// Fraction (0-1) of requests sent over mTLS
+	TlsRatio float64 `protobuf:"fixed64,7,opt,name=tls_ratio,json=tlsRatio,proto3" json:"tls_ratio,omitempty"`
+	// Fraction (0-1) of responses classified as success; 0 when none observed
+	SuccessRate float64 `protobuf:"fixed64,8,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	// Response latency quantiles in milliseconds; 0 when unknown
+	LatencyP50Ms  float64 `protobuf:"fixed64,9,opt,name=latency_p50_ms,json=latencyP50Ms,proto3" json:"latency_p50_ms,omitempty"`
+	LatencyP95Ms  float64 `protobuf:"fixed64,10,opt,name=latency_p95_ms,json=latencyP95Ms,proto3" json:"latency_p95_ms,omitempty"`
+	LatencyP99Ms  float64 `protobuf:"fixed64,11,opt,name=latency_p99_ms,json=latencyP99Ms,proto3" json:"latency_p99_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	mi := &file_mcp_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Edge) GetSrc() string {
+	if x != nil {
+		return x.Src
+	}
+	return ""
+}
+
+func (x *Edge) GetDst() string {
+	if x != nil {
+		return x.Dst
+	}
+	return ""
+}
+
+func (x *Edge) GetRps() float64 {
+	if x != nil {
+		return x.Rps
+	}
+	return 0
+}
+
+func (x *Edge) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *Edge) GetSrcNamespace() string {
+	if x != nil {
+		return x.SrcNamespace
+	}
+	return ""
+}
+
+func (x *Edge) GetDstNamespace() string {
+	if x != nil {
+		return x.DstNamespace
+	}
+	return ""
+}
+
+func (x *Edge) GetTlsRatio() float64 {
+	if x != nil {
+		return x.TlsRatio
+	}
+	return 0
+}
+
+func (x *Edge) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *Edge) GetLatencyP50Ms() float64 {
+	if x != nil {
+		return x.LatencyP50Ms
+	}
+	return 0
+}
+
+func (x *Edge) GetLatencyP95Ms() float64 {
+	if x != nil {
+		return x.LatencyP95Ms
+	}
+	return 0
+}
+
+func (x *Edge) GetLatencyP99Ms() float64 {
+	if x != nil {
+		return x.LatencyP99Ms
+	}
+	return 0
+}
+
+type AuthPolicy struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Spec      *structpb.Struct       `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	Namespace string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// True when the policy was created through this API (labelled
+	// app.kubernetes.io/managed-by=linkerd2-mcp) and so may be deleted by it
+	Managed       bool `protobuf:"varint,4,opt,name=managed,proto3" json:"managed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthPolicy) Reset() {
+	*x = AuthPolicy{}
+	mi := &file_mcp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthPolicy) ProtoMessage() {}
+
+func (x *AuthPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthPolicy.ProtoReflect.Descriptor instead.
+func (*AuthPolicy) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AuthPolicy) GetSpec() *structpb.Struct {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *AuthPolicy) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *AuthPolicy) GetManaged() bool {
+	if x != nil {
+		return x.Managed
+	}
+	return false
+}
+
+// A Linkerd policy (Server, ServerAuthorization, MeshTLSAuthentication,
+// NetworkAuthentication) or Gateway API route (HTTPRoute, GRPCRoute) resource
+type Resource struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Kind      string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Spec      *structpb.Struct       `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"`
+	// Linkerd retry.linkerd.io/* and timeout.linkerd.io/* annotations of routes
+	Annotations map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// True when the resource was created through this API
+	Managed       bool `protobuf:"varint,6,opt,name=managed,proto3" json:"managed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Resource) Reset() {
+	*x = Resource{}
+	mi := &file_mcp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resource) ProtoMessage() {}
+
+func (x *Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resource.ProtoReflect.Descriptor instead.
+func (*Resource) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Resource) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Resource) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Resource) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Resource) GetSpec() *structpb.Struct {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *Resource) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *Resource) GetManaged() bool {
+	if x != nil {
+		return x.Managed
+	}
+	return false
+}
+
+type MeshGraph struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keyed by namespace/name
+	Services     map[string]*Service    `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Edges        []*Edge                `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	AuthPolicies map[string]*AuthPolicy `protobuf:"bytes,3,rep,name=auth_policies,json=authPolicies,proto3" json:"auth_policies,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Keyed by namespace/kind/name (kind lower-cased)
+	Workloads map[string]*Workload `protobuf:"bytes,4,rep,name=workloads,proto3" json:"workloads,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Keyed by namespace/kind/name (kind lower-cased)
+	Resources map[string]*Resource `protobuf:"bytes,5,rep,name=resources,proto3" json:"resources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Freshness of each data source, keyed by source name
+	Sources       map[string]*SourceStatus `protobuf:"bytes,6,rep,name=sources,proto3" json:"sources,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshGraph) Reset() {
+	*x = MeshGraph{}
+	mi := &file_mcp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshGraph) ProtoMessage() {}
+
+func (x *MeshGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshGraph.ProtoReflect.Descriptor instead.
+func (*MeshGraph) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MeshGraph) GetServices() map[string]*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *MeshGraph) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *MeshGraph) GetAuthPolicies() map[string]*AuthPolicy {
+	if x != nil {
+		return x.AuthPolicies
+	}
+	return nil
+}
+
+func (x *MeshGraph) GetWorkloads() map[string]*Workload {
+	if x != nil {
+		return x.Workloads
+	}
+	return nil
+}
+
+func (x *MeshGraph) GetResources() map[string]*Resource {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *MeshGraph) GetSources() map[string]*SourceStatus {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// SourceStatus reports how current one data source feeding the graph is
+type SourceStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// prometheus, services, pods, workloads or policies
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// True once the source delivered a full view and is still healthy
+	Synced bool `protobuf:"varint,2,opt,name=synced,proto3" json:"synced,omitempty"`
+	// Last time the collector confirmed the source current
+	LastSuccess *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_success,json=lastSuccess,proto3" json:"last_success,omitempty"`
+	// Last failure, empty when the last check succeeded
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Set by the server when last_success is older than its staleness threshold
+	Stale bool `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`
+	// Graph fields the source feeds, e.g. "edges"
+	Fields        []string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SourceStatus) Reset() {
+	*x = SourceStatus{}
+	mi := &file_mcp_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SourceStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceStatus) ProtoMessage() {}
+
+func (x *SourceStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceStatus.ProtoReflect.Descriptor instead.
+func (*SourceStatus) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SourceStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
+func (x *SourceStatus) GetLastSuccess() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSuccess
+	}
+	return nil
+}
+
+func (x *SourceStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SourceStatus) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *SourceStatus) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// GraphStatus summarises how current a server's graph is
+type GraphStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when any source is stale
+	Stale bool `protobuf:"varint,1,opt,name=stale,proto3" json:"stale,omitempty"`
+	// True when every source is synced
+	Synced bool `protobuf:"varint,2,opt,name=synced,proto3" json:"synced,omitempty"`
+	// Graph fields fed by a stale or unsynced source
+	StaleFields   []string        `protobuf:"bytes,3,rep,name=stale_fields,json=staleFields,proto3" json:"stale_fields,omitempty"`
+	Sources       []*SourceStatus `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GraphStatus) Reset() {
+	*x = GraphStatus{}
+	mi := &file_mcp_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GraphStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphStatus) ProtoMessage() {}
+
+func (x *GraphStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphStatus.ProtoReflect.Descriptor instead.
+func (*GraphStatus) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GraphStatus) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GraphStatus) GetSynced() bool {
+	if x != nil {
+		return x.Synced
+	}
+	return false
+}
+
+func (x *GraphStatus) GetStaleFields() []string {
+	if x != nil {
+		return x.StaleFields
+	}
+	return nil
+}
+
+func (x *GraphStatus) GetSources() []*SourceStatus {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// Revision identifies a published state of the mesh graph. seq is shared by
+// all replicas, so two servers at the same seq hold the same graph.
+type Revision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Seq   uint64                 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Process that published it, e.g. "collector@collector-0:1"
+	Origin        string                 `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_mcp_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Revision) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Revision) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Revision) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
 type GetMeshGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Also populate json_graph for clients that predate the typed graph
+	IncludeJson bool `protobuf:"varint,1,opt,name=include_json,json=includeJson,proto3" json:"include_json,omitempty"`
+	// Only return the graph when its revision seq is greater than this;
+	// otherwise not_modified is set and graph is left empty
+	IfNewerThan   uint64 `protobuf:"varint,2,opt,name=if_newer_than,json=ifNewerThan,proto3" json:"if_newer_than,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeshGraphRequest) Reset() {
+	*x = GetMeshGraphRequest{}
+	mi := &file_mcp_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeshGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeshGraphRequest) ProtoMessage() {}
+
+func (x *GetMeshGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeshGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetMeshGraphRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetMeshGraphRequest) GetIncludeJson() bool {
+	if x != nil {
+		return x.IncludeJson
+	}
+	return false
+}
+
+func (x *GetMeshGraphRequest) GetIfNewerThan() uint64 {
+	if x != nil {
+		return x.IfNewerThan
+	}
+	return 0
+}
+
+type GetMeshGraphResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: JSON encoding of the graph, only set when include_json is requested
+	JsonGraph     string       `protobuf:"bytes,1,opt,name=json_graph,json=jsonGraph,proto3" json:"json_graph,omitempty"`
+	Graph         *MeshGraph   `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	Revision      *Revision    `protobuf:"bytes,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	NotModified   bool         `protobuf:"varint,4,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
+	Status        *GraphStatus `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeshGraphResponse) Reset() {
+	*x = GetMeshGraphResponse{}
+	mi := &file_mcp_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeshGraphResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeshGraphResponse) ProtoMessage() {}
+
+func (x *GetMeshGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeshGraphResponse.ProtoReflect.Descriptor instead.
+func (*GetMeshGraphResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMeshGraphResponse) GetJsonGraph() string {
+	if x != nil {
+		return x.JsonGraph
+	}
+	return ""
+}
+
+func (x *GetMeshGraphResponse) GetGraph() *MeshGraph {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+func (x *GetMeshGraphResponse) GetRevision() *Revision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+func (x *GetMeshGraphResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+func (x *GetMeshGraphResponse) GetStatus() *GraphStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_mcp_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{11}
+}
+
+type GetStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *GraphStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Revision      *Revision              `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_mcp_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetStatusResponse) GetStatus() *GraphStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetRevision() *Revision {
+	if x != nil {
+		return x.Revision
+	}
+	return nil
+}
+
+type GetCallGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only edges whose source or destination is in this namespace ("" for all)
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Only edges that are not fully mTLS'd
+	NonTlsOnly bool `protobuf:"varint,2,opt,name=non_tls_only,json=nonTlsOnly,proto3" json:"non_tls_only,omitempty"`
+	// Only edges whose source workload has this name (its dependencies)
+	Source        string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCallGraphRequest) Reset() {
+	*x = GetCallGraphRequest{}
+	mi := &file_mcp_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCallGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallGraphRequest) ProtoMessage() {}
+
+func (x *GetCallGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallGraphRequest.ProtoReflect.Descriptor instead.
+func (*GetCallGraphRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetCallGraphRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetCallGraphRequest) GetNonTlsOnly() bool {
+	if x != nil {
+		return x.NonTlsOnly
+	}
+	return false
+}
+
+func (x *GetCallGraphRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type GetCallGraphResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edges         []*Edge                `protobuf:"bytes,1,rep,name=edges,proto3" json:"edges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCallGraphResponse) Reset() {
+	*x = GetCallGraphResponse{}
+	mi := &file_mcp_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCallGraphResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallGraphResponse) ProtoMessage() {}
+
+func (x *GetCallGraphResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallGraphResponse.ProtoReflect.Descriptor instead.
+func (*GetCallGraphResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetCallGraphResponse) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+type WatchMeshGraphRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Last version the client has seen; events after it are replayed when still
+	// buffered, otherwise (or when 0) the stream starts with a snapshot
+	ResumeFromVersion uint64 `protobuf:"varint,1,opt,name=resume_from_version,json=resumeFromVersion,proto3" json:"resume_from_version,omitempty"`
+	// Epoch of the events the client has seen; versions only resume within
+	// the same epoch
+	ResumeEpoch   string `protobuf:"bytes,2,opt,name=resume_epoch,json=resumeEpoch,proto3" json:"resume_epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMeshGraphRequest) Reset() {
+	*x = WatchMeshGraphRequest{}
+	mi := &file_mcp_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMeshGraphRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMeshGraphRequest) ProtoMessage() {}
+
+func (x *WatchMeshGraphRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMeshGraphRequest.ProtoReflect.Descriptor instead.
+func (*WatchMeshGraphRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchMeshGraphRequest) GetResumeFromVersion() uint64 {
+	if x != nil {
+		return x.ResumeFromVersion
+	}
+	return 0
+}
+
+func (x *WatchMeshGraphRequest) GetResumeEpoch() string {
+	if x != nil {
+		return x.ResumeEpoch
+	}
+	return ""
+}
+
+type MeshGraphEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Monotonic per-server version; pass it back as resume_from_version
+	Version uint64              `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Type    MeshGraphEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=mcp.v1.MeshGraphEvent_Type" json:"type,omitempty"`
+	// Key of the changed service, edge ("srcns/src->dstns/dst"), policy,
+	// workload or resource
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// Set on SNAPSHOT events
+	Snapshot *MeshGraph  `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Service  *Service    `protobuf:"bytes,5,opt,name=service,proto3" json:"service,omitempty"`
+	Edge     *Edge       `protobuf:"bytes,6,opt,name=edge,proto3" json:"edge,omitempty"`
+	Policy   *AuthPolicy `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`
+	Workload *Workload   `protobuf:"bytes,8,opt,name=workload,proto3" json:"workload,omitempty"`
+	Resource *Resource   `protobuf:"bytes,9,opt,name=resource,proto3" json:"resource,omitempty"`
+	// Identifies the server process numbering the versions; pass it back as
+	// resume_epoch
+	Epoch         string `protobuf:"bytes,10,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshGraphEvent) Reset() {
+	*x = MeshGraphEvent{}
+	mi := &file_mcp_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshGraphEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshGraphEvent) ProtoMessage() {}
+
+func (x *MeshGraphEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshGraphEvent.ProtoReflect.Descriptor instead.
+func (*MeshGraphEvent) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MeshGraphEvent) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MeshGraphEvent) GetType() MeshGraphEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return MeshGraphEvent_TYPE_UNSPECIFIED
+}
+
+func (x *MeshGraphEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MeshGraphEvent) GetSnapshot() *MeshGraph {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *MeshGraphEvent) GetService() *Service {
+	if x != nil {
+		return x.Service
+	}
+	return nil
+}
+
+func (x *MeshGraphEvent) GetEdge() *Edge {
+	if x != nil {
+		return x.Edge
+	}
+	return nil
+}
+
+func (x *MeshGraphEvent) GetPolicy() *AuthPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *MeshGraphEvent) GetWorkload() *Workload {
+	if x != nil {
+		return x.Workload
+	}
+	return nil
+}
+
+func (x *MeshGraphEvent) GetResource() *Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *MeshGraphEvent) GetEpoch() string {
+	if x != nil {
+		return x.Epoch
+	}
+	return ""
+}
+
+// Mutation: ApplyAuthorizationPolicy
+type ApplyAuthorizationPolicyRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	JsonSpec  string                 `protobuf:"bytes,3,opt,name=json_spec,json=jsonSpec,proto3" json:"json_spec,omitempty"`
+	// Take over fields of the policy owned by other field managers (e.g. kubectl)
+	Force bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	// Take over an existing policy not created by linkerd2-mcp, labelling it as
+	// managed (and so deletable) from then on
+	Adopt         bool `protobuf:"varint,5,opt,name=adopt,proto3" json:"adopt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyAuthorizationPolicyRequest) Reset() {
+	*x = ApplyAuthorizationPolicyRequest{}
+	mi := &file_mcp_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyAuthorizationPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyAuthorizationPolicyRequest) ProtoMessage() {}
+
+func (x *ApplyAuthorizationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyAuthorizationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyAuthorizationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApplyAuthorizationPolicyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ApplyAuthorizationPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApplyAuthorizationPolicyRequest) GetJsonSpec() string {
+	if x != nil {
+		return x.JsonSpec
+	}
+	return ""
+}
+
+func (x *ApplyAuthorizationPolicyRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ApplyAuthorizationPolicyRequest) GetAdopt() bool {
+	if x != nil {
+		return x.Adopt
+	}
+	return false
+}
+
+type ApplyAuthorizationPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when the policy was stored in the cluster (or, without cluster
+	// access, handed to the collector)
+	Accepted bool   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message  string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Why the policy was rejected, one entry per offending field
+	Errors []*FieldError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// The stored object, when applied directly
+	ResourceVersion string `protobuf:"bytes,4,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	Uid             string `protobuf:"bytes,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	Generation      int64  `protobuf:"varint,6,opt,name=generation,proto3" json:"generation,omitempty"`
+	// Fields owned by other field managers that blocked the apply; retry with
+	// force to take them over
+	Conflicts     []*FieldError `protobuf:"bytes,7,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMeshGraphRequest) Reset() {
-	*x = GetMeshGraphRequest{}
-	mi := &file_mcp_proto_msgTypes[0]
+func (x *ApplyAuthorizationPolicyResponse) Reset() {
+	*x = ApplyAuthorizationPolicyResponse{}
+	mi := &file_mcp_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMeshGraphRequest) String() string {
+func (x *ApplyAuthorizationPolicyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMeshGraphRequest) ProtoMessage() {}
+func (*ApplyAuthorizationPolicyResponse) ProtoMessage() {}
 
-func (x *GetMeshGraphRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[0]
+func (x *ApplyAuthorizationPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,33 +1533,84 @@ func (x *GetMeshGraphRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMeshGraphRequest.ProtoReflect.Descriptor instead.
-func (*GetMeshGraphRequest) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ApplyAuthorizationPolicyResponse.ProtoReflect.Descriptor instead.
+func (*ApplyAuthorizationPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{18}
 }
 
-type GetMeshGraphResponse struct {
+func (x *ApplyAuthorizationPolicyResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *ApplyAuthorizationPolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyAuthorizationPolicyResponse) GetErrors() []*FieldError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ApplyAuthorizationPolicyResponse) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *ApplyAuthorizationPolicyResponse) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *ApplyAuthorizationPolicyResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *ApplyAuthorizationPolicyResponse) GetConflicts() []*FieldError {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+// Mutation: DeleteAuthorizationPolicy
+type DeleteAuthorizationPolicyRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	JsonGraph     string                 `protobuf:"bytes,1,opt,name=json_graph,json=jsonGraph,proto3" json:"json_graph,omitempty"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMeshGraphResponse) Reset() {
-	*x = GetMeshGraphResponse{}
-	mi := &file_mcp_proto_msgTypes[1]
+func (x *DeleteAuthorizationPolicyRequest) Reset() {
+	*x = DeleteAuthorizationPolicyRequest{}
+	mi := &file_mcp_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMeshGraphResponse) String() string {
+func (x *DeleteAuthorizationPolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMeshGraphResponse) ProtoMessage() {}
+func (*DeleteAuthorizationPolicyRequest) ProtoMessage() {}
 
-func (x *GetMeshGraphResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[1]
+func (x *DeleteAuthorizationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -92,43 +1621,50 @@ func (x *GetMeshGraphResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMeshGraphResponse.ProtoReflect.Descriptor instead.
-func (*GetMeshGraphResponse) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DeleteAuthorizationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAuthorizationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetMeshGraphResponse) GetJsonGraph() string {
+func (x *DeleteAuthorizationPolicyRequest) GetNamespace() string {
 	if x != nil {
-		return x.JsonGraph
+		return x.Namespace
 	}
 	return ""
 }
 
-// Mutation: ApplyAuthorizationPolicy
-type ApplyAuthorizationPolicyRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	JsonSpec      string                 `protobuf:"bytes,3,opt,name=json_spec,json=jsonSpec,proto3" json:"json_spec,omitempty"`
+func (x *DeleteAuthorizationPolicyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteAuthorizationPolicyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when the policy was deleted from the cluster (or, without cluster
+	// access, its deletion handed to the collector)
+	Deleted       bool   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ApplyAuthorizationPolicyRequest) Reset() {
-	*x = ApplyAuthorizationPolicyRequest{}
-	mi := &file_mcp_proto_msgTypes[2]
+func (x *DeleteAuthorizationPolicyResponse) Reset() {
+	*x = DeleteAuthorizationPolicyResponse{}
+	mi := &file_mcp_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApplyAuthorizationPolicyRequest) String() string {
+func (x *DeleteAuthorizationPolicyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyAuthorizationPolicyRequest) ProtoMessage() {}
+func (*DeleteAuthorizationPolicyResponse) ProtoMessage() {}
 
-func (x *ApplyAuthorizationPolicyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[2]
+func (x *DeleteAuthorizationPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,55 +1675,149 @@ func (x *ApplyAuthorizationPolicyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyAuthorizationPolicyRequest.ProtoReflect.Descriptor instead.
-func (*ApplyAuthorizationPolicyRequest) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DeleteAuthorizationPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAuthorizationPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ApplyAuthorizationPolicyRequest) GetNamespace() string {
+func (x *DeleteAuthorizationPolicyResponse) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *DeleteAuthorizationPolicyResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Mutations: ApplyHTTPRoute, ApplyGRPCRoute
+type ApplyRouteRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The route's spec: parentRefs, hostnames and rules (matches, filters,
+	// backendRefs with weights, timeouts)
+	JsonSpec string `protobuf:"bytes,3,opt,name=json_spec,json=jsonSpec,proto3" json:"json_spec,omitempty"`
+	// Take over fields of the route owned by other field managers
+	Force bool `protobuf:"varint,4,opt,name=force,proto3" json:"force,omitempty"`
+	// Linkerd retry and timeout annotations, e.g. retry.linkerd.io/http: 5xx
+	Annotations map[string]string `protobuf:"bytes,5,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Take over an existing route not created by linkerd2-mcp, labelling it as
+	// managed from then on
+	Adopt         bool `protobuf:"varint,6,opt,name=adopt,proto3" json:"adopt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyRouteRequest) Reset() {
+	*x = ApplyRouteRequest{}
+	mi := &file_mcp_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRouteRequest) ProtoMessage() {}
+
+func (x *ApplyRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRouteRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRouteRequest) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ApplyRouteRequest) GetNamespace() string {
 	if x != nil {
 		return x.Namespace
 	}
 	return ""
 }
 
-func (x *ApplyAuthorizationPolicyRequest) GetName() string {
+func (x *ApplyRouteRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *ApplyAuthorizationPolicyRequest) GetJsonSpec() string {
+func (x *ApplyRouteRequest) GetJsonSpec() string {
 	if x != nil {
 		return x.JsonSpec
 	}
 	return ""
 }
 
-type ApplyAuthorizationPolicyResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+func (x *ApplyRouteRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *ApplyRouteRequest) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *ApplyRouteRequest) GetAdopt() bool {
+	if x != nil {
+		return x.Adopt
+	}
+	return false
+}
+
+type ApplyRouteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when the route was stored in the cluster (or, without cluster
+	// access, handed to the collector)
+	Accepted bool   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Message  string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Why the route was rejected, one entry per offending field
+	Errors []*FieldError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	// The stored object, when applied directly
+	ResourceVersion string `protobuf:"bytes,4,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	Uid             string `protobuf:"bytes,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	Generation      int64  `protobuf:"varint,6,opt,name=generation,proto3" json:"generation,omitempty"`
+	// Fields owned by other field managers that blocked the apply
+	Conflicts     []*FieldError `protobuf:"bytes,7,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ApplyAuthorizationPolicyResponse) Reset() {
-	*x = ApplyAuthorizationPolicyResponse{}
-	mi := &file_mcp_proto_msgTypes[3]
+func (x *ApplyRouteResponse) Reset() {
+	*x = ApplyRouteResponse{}
+	mi := &file_mcp_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApplyAuthorizationPolicyResponse) String() string {
+func (x *ApplyRouteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyAuthorizationPolicyResponse) ProtoMessage() {}
+func (*ApplyRouteResponse) ProtoMessage() {}
 
-func (x *ApplyAuthorizationPolicyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_mcp_proto_msgTypes[3]
+func (x *ApplyRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,19 +1828,117 @@ func (x *ApplyAuthorizationPolicyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyAuthorizationPolicyResponse.ProtoReflect.Descriptor instead.
-func (*ApplyAuthorizationPolicyResponse) Descriptor() ([]byte, []int) {
-	return file_mcp_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ApplyRouteResponse.ProtoReflect.Descriptor instead.
+func (*ApplyRouteResponse) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *ApplyAuthorizationPolicyResponse) GetAccepted() bool {
+func (x *ApplyRouteResponse) GetAccepted() bool {
 	if x != nil {
 		return x.Accepted
 	}
 	return false
 }
 
-func (x *ApplyAuthorizationPolicyResponse) GetMessage() string {
+func (x *ApplyRouteResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyRouteResponse) GetErrors() []*FieldError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ApplyRouteResponse) GetResourceVersion() string {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return ""
+}
+
+func (x *ApplyRouteResponse) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *ApplyRouteResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *ApplyRouteResponse) GetConflicts() []*FieldError {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+// A validation failure of one field of a requested resource
+type FieldError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path of the field, e.g. "spec.targetRef.kind"
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Kubernetes cause type, e.g. "FieldValueRequired" or "FieldValueInvalid"
+	Type          string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldError) Reset() {
+	*x = FieldError{}
+	mi := &file_mcp_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldError) ProtoMessage() {}
+
+func (x *FieldError) ProtoReflect() protoreflect.Message {
+	mi := &file_mcp_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldError.ProtoReflect.Descriptor instead.
+func (*FieldError) Descriptor() ([]byte, []int) {
+	return file_mcp_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FieldError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldError) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FieldError) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
@@ -221,21 +1949,204 @@ var File_mcp_proto protoreflect.FileDescriptor
 
 const file_mcp_proto_rawDesc = "" +
 	"\n" +
-	"\tmcp.proto\x12\x06mcp.v1\"\x15\n" +
-	"\x13GetMeshGraphRequest\"5\n" +
+	"\tmcp.proto\x12\x06mcp.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x01\n" +
+	"\aService\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x16\n" +
+	"\x06meshed\x18\x03 \x01(\bR\x06meshed\x12\x1f\n" +
+	"\vmeshed_pods\x18\x04 \x01(\x05R\n" +
+	"meshedPods\x12\x1d\n" +
+	"\n" +
+	"total_pods\x18\x05 \x01(\x05R\ttotalPods\"\x83\x02\n" +
+	"\bWorkload\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\breplicas\x18\x04 \x01(\x05R\breplicas\x12\x1d\n" +
+	"\n" +
+	"ready_pods\x18\x05 \x01(\x05R\treadyPods\x12\x1f\n" +
+	"\vmeshed_pods\x18\x06 \x01(\x05R\n" +
+	"meshedPods\x12#\n" +
+	"\rproxy_version\x18\a \x01(\tR\fproxyVersion\x12\x14\n" +
+	"\x05owner\x18\b \x01(\tR\x05owner\x12\x1a\n" +
+	"\bservices\x18\t \x03(\tR\bservices\"\xca\x02\n" +
+	"\x04Edge\x12\x10\n" +
+	"\x03src\x18\x01 \x01(\tR\x03src\x12\x10\n" +
+	"\x03dst\x18\x02 \x01(\tR\x03dst\x12\x10\n" +
+	"\x03rps\x18\x03 \x01(\x01R\x03rps\x12\x10\n" +
+	"\x03tls\x18\x04 \x01(\bR\x03tls\x12#\n" +
+	"\rsrc_namespace\x18\x05 \x01(\tR\fsrcNamespace\x12#\n" +
+	"\rdst_namespace\x18\x06 \x01(\tR\fdstNamespace\x12\x1b\n" +
+	"\ttls_ratio\x18\a \x01(\x01R\btlsRatio\x12!\n" +
+	"\fsuccess_rate\x18\b \x01(\x01R\vsuccessRate\x12$\n" +
+	"\x0elatency_p50_ms\x18\t \x01(\x01R\flatencyP50Ms\x12$\n" +
+	"\x0elatency_p95_ms\x18\n" +
+	" \x01(\x01R\flatencyP95Ms\x12$\n" +
+	"\x0elatency_p99_ms\x18\v \x01(\x01R\flatencyP99Ms\"\x85\x01\n" +
+	"\n" +
+	"AuthPolicy\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x04spec\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x04spec\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x18\n" +
+	"\amanaged\x18\x04 \x01(\bR\amanaged\"\x9c\x02\n" +
+	"\bResource\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12+\n" +
+	"\x04spec\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04spec\x12C\n" +
+	"\vannotations\x18\x05 \x03(\v2!.mcp.v1.Resource.AnnotationsEntryR\vannotations\x12\x18\n" +
+	"\amanaged\x18\x06 \x01(\bR\amanaged\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x06\n" +
+	"\tMeshGraph\x12;\n" +
+	"\bservices\x18\x01 \x03(\v2\x1f.mcp.v1.MeshGraph.ServicesEntryR\bservices\x12\"\n" +
+	"\x05edges\x18\x02 \x03(\v2\f.mcp.v1.EdgeR\x05edges\x12H\n" +
+	"\rauth_policies\x18\x03 \x03(\v2#.mcp.v1.MeshGraph.AuthPoliciesEntryR\fauthPolicies\x12>\n" +
+	"\tworkloads\x18\x04 \x03(\v2 .mcp.v1.MeshGraph.WorkloadsEntryR\tworkloads\x12>\n" +
+	"\tresources\x18\x05 \x03(\v2 .mcp.v1.MeshGraph.ResourcesEntryR\tresources\x128\n" +
+	"\asources\x18\x06 \x03(\v2\x1e.mcp.v1.MeshGraph.SourcesEntryR\asources\x1aL\n" +
+	"\rServicesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12%\n" +
+	"\x05value\x18\x02 \x01(\v2\x0f.mcp.v1.ServiceR\x05value:\x028\x01\x1aS\n" +
+	"\x11AuthPoliciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12(\n" +
+	"\x05value\x18\x02 \x01(\v2\x12.mcp.v1.AuthPolicyR\x05value:\x028\x01\x1aN\n" +
+	"\x0eWorkloadsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.mcp.v1.WorkloadR\x05value:\x028\x01\x1aN\n" +
+	"\x0eResourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\v2\x10.mcp.v1.ResourceR\x05value:\x028\x01\x1aP\n" +
+	"\fSourcesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.mcp.v1.SourceStatusR\x05value:\x028\x01\"\xbd\x01\n" +
+	"\fSourceStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06synced\x18\x02 \x01(\bR\x06synced\x12=\n" +
+	"\flast_success\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlastSuccess\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\x12\x16\n" +
+	"\x06fields\x18\x06 \x03(\tR\x06fields\"\x8e\x01\n" +
+	"\vGraphStatus\x12\x14\n" +
+	"\x05stale\x18\x01 \x01(\bR\x05stale\x12\x16\n" +
+	"\x06synced\x18\x02 \x01(\bR\x06synced\x12!\n" +
+	"\fstale_fields\x18\x03 \x03(\tR\vstaleFields\x12.\n" +
+	"\asources\x18\x04 \x03(\v2\x14.mcp.v1.SourceStatusR\asources\"n\n" +
+	"\bRevision\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x04R\x03seq\x12\x16\n" +
+	"\x06origin\x18\x02 \x01(\tR\x06origin\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"\\\n" +
+	"\x13GetMeshGraphRequest\x12!\n" +
+	"\finclude_json\x18\x01 \x01(\bR\vincludeJson\x12\"\n" +
+	"\rif_newer_than\x18\x02 \x01(\x04R\vifNewerThan\"\xdc\x01\n" +
 	"\x14GetMeshGraphResponse\x12\x1d\n" +
 	"\n" +
-	"json_graph\x18\x01 \x01(\tR\tjsonGraph\"p\n" +
+	"json_graph\x18\x01 \x01(\tR\tjsonGraph\x12'\n" +
+	"\x05graph\x18\x02 \x01(\v2\x11.mcp.v1.MeshGraphR\x05graph\x12,\n" +
+	"\brevision\x18\x03 \x01(\v2\x10.mcp.v1.RevisionR\brevision\x12!\n" +
+	"\fnot_modified\x18\x04 \x01(\bR\vnotModified\x12+\n" +
+	"\x06status\x18\x05 \x01(\v2\x13.mcp.v1.GraphStatusR\x06status\"\x12\n" +
+	"\x10GetStatusRequest\"n\n" +
+	"\x11GetStatusResponse\x12+\n" +
+	"\x06status\x18\x01 \x01(\v2\x13.mcp.v1.GraphStatusR\x06status\x12,\n" +
+	"\brevision\x18\x02 \x01(\v2\x10.mcp.v1.RevisionR\brevision\"m\n" +
+	"\x13GetCallGraphRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12 \n" +
+	"\fnon_tls_only\x18\x02 \x01(\bR\n" +
+	"nonTlsOnly\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\":\n" +
+	"\x14GetCallGraphResponse\x12\"\n" +
+	"\x05edges\x18\x01 \x03(\v2\f.mcp.v1.EdgeR\x05edges\"j\n" +
+	"\x15WatchMeshGraphRequest\x12.\n" +
+	"\x13resume_from_version\x18\x01 \x01(\x04R\x11resumeFromVersion\x12!\n" +
+	"\fresume_epoch\x18\x02 \x01(\tR\vresumeEpoch\"\xb9\x05\n" +
+	"\x0eMeshGraphEvent\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x04R\aversion\x12/\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1b.mcp.v1.MeshGraphEvent.TypeR\x04type\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12-\n" +
+	"\bsnapshot\x18\x04 \x01(\v2\x11.mcp.v1.MeshGraphR\bsnapshot\x12)\n" +
+	"\aservice\x18\x05 \x01(\v2\x0f.mcp.v1.ServiceR\aservice\x12 \n" +
+	"\x04edge\x18\x06 \x01(\v2\f.mcp.v1.EdgeR\x04edge\x12*\n" +
+	"\x06policy\x18\a \x01(\v2\x12.mcp.v1.AuthPolicyR\x06policy\x12,\n" +
+	"\bworkload\x18\b \x01(\v2\x10.mcp.v1.WorkloadR\bworkload\x12,\n" +
+	"\bresource\x18\t \x01(\v2\x10.mcp.v1.ResourceR\bresource\x12\x14\n" +
+	"\x05epoch\x18\n" +
+	" \x01(\tR\x05epoch\"\xaf\x02\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bSNAPSHOT\x10\x01\x12\x11\n" +
+	"\rSERVICE_ADDED\x10\x02\x12\x13\n" +
+	"\x0fSERVICE_UPDATED\x10\x03\x12\x13\n" +
+	"\x0fSERVICE_REMOVED\x10\x04\x12\x0e\n" +
+	"\n" +
+	"EDGE_ADDED\x10\x05\x12\x10\n" +
+	"\fEDGE_UPDATED\x10\x06\x12\x10\n" +
+	"\fEDGE_REMOVED\x10\a\x12\x12\n" +
+	"\x0ePOLICY_APPLIED\x10\b\x12\x12\n" +
+	"\x0ePOLICY_REMOVED\x10\t\x12\x12\n" +
+	"\x0eWORKLOAD_ADDED\x10\n" +
+	"\x12\x14\n" +
+	"\x10WORKLOAD_UPDATED\x10\v\x12\x14\n" +
+	"\x10WORKLOAD_REMOVED\x10\f\x12\x14\n" +
+	"\x10RESOURCE_APPLIED\x10\r\x12\x14\n" +
+	"\x10RESOURCE_REMOVED\x10\x0e\"\x9c\x01\n" +
 	"\x1fApplyAuthorizationPolicyRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
-	"\tjson_spec\x18\x03 \x01(\tR\bjsonSpec\"X\n" +
+	"\tjson_spec\x18\x03 \x01(\tR\bjsonSpec\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\x12\x14\n" +
+	"\x05adopt\x18\x05 \x01(\bR\x05adopt\"\x93\x02\n" +
 	" ApplyAuthorizationPolicyResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xc7\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x06errors\x18\x03 \x03(\v2\x12.mcp.v1.FieldErrorR\x06errors\x12)\n" +
+	"\x10resource_version\x18\x04 \x01(\tR\x0fresourceVersion\x12\x10\n" +
+	"\x03uid\x18\x05 \x01(\tR\x03uid\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x06 \x01(\x03R\n" +
+	"generation\x120\n" +
+	"\tconflicts\x18\a \x03(\v2\x12.mcp.v1.FieldErrorR\tconflicts\"T\n" +
+	" DeleteAuthorizationPolicyRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"W\n" +
+	"!DeleteAuthorizationPolicyResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9c\x02\n" +
+	"\x11ApplyRouteRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tjson_spec\x18\x03 \x01(\tR\bjsonSpec\x12\x14\n" +
+	"\x05force\x18\x04 \x01(\bR\x05force\x12L\n" +
+	"\vannotations\x18\x05 \x03(\v2*.mcp.v1.ApplyRouteRequest.AnnotationsEntryR\vannotations\x12\x14\n" +
+	"\x05adopt\x18\x06 \x01(\bR\x05adopt\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x85\x02\n" +
+	"\x12ApplyRouteResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x06errors\x18\x03 \x03(\v2\x12.mcp.v1.FieldErrorR\x06errors\x12)\n" +
+	"\x10resource_version\x18\x04 \x01(\tR\x0fresourceVersion\x12\x10\n" +
+	"\x03uid\x18\x05 \x01(\tR\x03uid\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x06 \x01(\x03R\n" +
+	"generation\x120\n" +
+	"\tconflicts\x18\a \x03(\v2\x12.mcp.v1.FieldErrorR\tconflicts\"P\n" +
+	"\n" +
+	"FieldError\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage2\xa3\x05\n" +
 	"\vMeshContext\x12I\n" +
-	"\fGetMeshGraph\x12\x1b.mcp.v1.GetMeshGraphRequest\x1a\x1c.mcp.v1.GetMeshGraphResponse\x12m\n" +
-	"\x18ApplyAuthorizationPolicy\x12'.mcp.v1.ApplyAuthorizationPolicyRequest\x1a(.mcp.v1.ApplyAuthorizationPolicyResponseB5Z3github.com/eli-nomasec/linkerd2-mcp/proto/mcp/v1;v1b\x06proto3"
+	"\fGetMeshGraph\x12\x1b.mcp.v1.GetMeshGraphRequest\x1a\x1c.mcp.v1.GetMeshGraphResponse\x12I\n" +
+	"\x0eWatchMeshGraph\x12\x1d.mcp.v1.WatchMeshGraphRequest\x1a\x16.mcp.v1.MeshGraphEvent0\x01\x12I\n" +
+	"\fGetCallGraph\x12\x1b.mcp.v1.GetCallGraphRequest\x1a\x1c.mcp.v1.GetCallGraphResponse\x12m\n" +
+	"\x18ApplyAuthorizationPolicy\x12'.mcp.v1.ApplyAuthorizationPolicyRequest\x1a(.mcp.v1.ApplyAuthorizationPolicyResponse\x12p\n" +
+	"\x19DeleteAuthorizationPolicy\x12(.mcp.v1.DeleteAuthorizationPolicyRequest\x1a).mcp.v1.DeleteAuthorizationPolicyResponse\x12G\n" +
+	"\x0eApplyHTTPRoute\x12\x19.mcp.v1.ApplyRouteRequest\x1a\x1a.mcp.v1.ApplyRouteResponse\x12G\n" +
+	"\x0eApplyGRPCRoute\x12\x19.mcp.v1.ApplyRouteRequest\x1a\x1a.mcp.v1.ApplyRouteResponse\x12@\n" +
+	"\tGetStatus\x12\x18.mcp.v1.GetStatusRequest\x1a\x19.mcp.v1.GetStatusResponseB5Z3github.com/eli-nomasec/linkerd2-mcp/proto/mcp/v1;v1b\x06proto3"
 
 var (
 	file_mcp_proto_rawDescOnce sync.Once
@@ -249,23 +2160,101 @@ func file_mcp_proto_rawDescGZIP() []byte {
 	return file_mcp_proto_rawDescData
 }
 
-var file_mcp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_mcp_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_mcp_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_mcp_proto_goTypes = []any{
-	(*GetMeshGraphRequest)(nil),              // 0: mcp.v1.GetMeshGraphRequest
-	(*GetMeshGraphResponse)(nil),             // 1: mcp.v1.GetMeshGraphResponse
-	(*ApplyAuthorizationPolicyRequest)(nil),  // 2: mcp.v1.ApplyAuthorizationPolicyRequest
-	(*ApplyAuthorizationPolicyResponse)(nil), // 3: mcp.v1.ApplyAuthorizationPolicyResponse
+	(MeshGraphEvent_Type)(0),                  // 0: mcp.v1.MeshGraphEvent.Type
+	(*Service)(nil),                           // 1: mcp.v1.Service
+	(*Workload)(nil),                          // 2: mcp.v1.Workload
+	(*Edge)(nil),                              // 3: mcp.v1.Edge
+	(*AuthPolicy)(nil),                        // 4: mcp.v1.AuthPolicy
+	(*Resource)(nil),                          // 5: mcp.v1.Resource
+	(*MeshGraph)(nil),                         // 6: mcp.v1.MeshGraph
+	(*SourceStatus)(nil),                      // 7: mcp.v1.SourceStatus
+	(*GraphStatus)(nil),                       // 8: mcp.v1.GraphStatus
+	(*Revision)(nil),                          // 9: mcp.v1.Revision
+	(*GetMeshGraphRequest)(nil),               // 10: mcp.v1.GetMeshGraphRequest
+	(*GetMeshGraphResponse)(nil),              // 11: mcp.v1.GetMeshGraphResponse
+	(*GetStatusRequest)(nil),                  // 12: mcp.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),                 // 13: mcp.v1.GetStatusResponse
+	(*GetCallGraphRequest)(nil),               // 14: mcp.v1.GetCallGraphRequest
+	(*GetCallGraphResponse)(nil),              // 15: mcp.v1.GetCallGraphResponse
+	(*WatchMeshGraphRequest)(nil),             // 16: mcp.v1.WatchMeshGraphRequest
+	(*MeshGraphEvent)(nil),                    // 17: mcp.v1.MeshGraphEvent
+	(*ApplyAuthorizationPolicyRequest)(nil),   // 18: mcp.v1.ApplyAuthorizationPolicyRequest
+	(*ApplyAuthorizationPolicyResponse)(nil),  // 19: mcp.v1.ApplyAuthorizationPolicyResponse
+	(*DeleteAuthorizationPolicyRequest)(nil),  // 20: mcp.v1.DeleteAuthorizationPolicyRequest
+	(*DeleteAuthorizationPolicyResponse)(nil), // 21: mcp.v1.DeleteAuthorizationPolicyResponse
+	(*ApplyRouteRequest)(nil),                 // 22: mcp.v1.ApplyRouteRequest
+	(*ApplyRouteResponse)(nil),                // 23: mcp.v1.ApplyRouteResponse
+	(*FieldError)(nil),                        // 24: mcp.v1.FieldError
+	nil,                                       // 25: mcp.v1.Resource.AnnotationsEntry
+	nil,                                       // 26: mcp.v1.MeshGraph.ServicesEntry
+	nil,                                       // 27: mcp.v1.MeshGraph.AuthPoliciesEntry
+	nil,                                       // 28: mcp.v1.MeshGraph.WorkloadsEntry
+	nil,                                       // 29: mcp.v1.MeshGraph.ResourcesEntry
+	nil,                                       // 30: mcp.v1.MeshGraph.SourcesEntry
+	nil,                                       // 31: mcp.v1.ApplyRouteRequest.AnnotationsEntry
+	(*structpb.Struct)(nil),                   // 32: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),             // 33: google.protobuf.Timestamp
 }
 var file_mcp_proto_depIdxs = []int32{
-	0, // 0: mcp.v1.MeshContext.GetMeshGraph:input_type -> mcp.v1.GetMeshGraphRequest
-	2, // 1: mcp.v1.MeshContext.ApplyAuthorizationPolicy:input_type -> mcp.v1.ApplyAuthorizationPolicyRequest
-	1, // 2: mcp.v1.MeshContext.GetMeshGraph:output_type -> mcp.v1.GetMeshGraphResponse
-	3, // 3: mcp.v1.MeshContext.ApplyAuthorizationPolicy:output_type -> mcp.v1.ApplyAuthorizationPolicyResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	32, // 0: mcp.v1.AuthPolicy.spec:type_name -> google.protobuf.Struct
+	32, // 1: mcp.v1.Resource.spec:type_name -> google.protobuf.Struct
+	25, // 2: mcp.v1.Resource.annotations:type_name -> mcp.v1.Resource.AnnotationsEntry
+	26, // 3: mcp.v1.MeshGraph.services:type_name -> mcp.v1.MeshGraph.ServicesEntry
+	3,  // 4: mcp.v1.MeshGraph.edges:type_name -> mcp.v1.Edge
+	27, // 5: mcp.v1.MeshGraph.auth_policies:type_name -> mcp.v1.MeshGraph.AuthPoliciesEntry
+	28, // 6: mcp.v1.MeshGraph.workloads:type_name -> mcp.v1.MeshGraph.WorkloadsEntry
+	29, // 7: mcp.v1.MeshGraph.resources:type_name -> mcp.v1.MeshGraph.ResourcesEntry
+	30, // 8: mcp.v1.MeshGraph.sources:type_name -> mcp.v1.MeshGraph.SourcesEntry
+	33, // 9: mcp.v1.SourceStatus.last_success:type_name -> google.protobuf.Timestamp
+	7,  // 10: mcp.v1.GraphStatus.sources:type_name -> mcp.v1.SourceStatus
+	33, // 11: mcp.v1.Revision.timestamp:type_name -> google.protobuf.Timestamp
+	6,  // 12: mcp.v1.GetMeshGraphResponse.graph:type_name -> mcp.v1.MeshGraph
+	9,  // 13: mcp.v1.GetMeshGraphResponse.revision:type_name -> mcp.v1.Revision
+	8,  // 14: mcp.v1.GetMeshGraphResponse.status:type_name -> mcp.v1.GraphStatus
+	8,  // 15: mcp.v1.GetStatusResponse.status:type_name -> mcp.v1.GraphStatus
+	9,  // 16: mcp.v1.GetStatusResponse.revision:type_name -> mcp.v1.Revision
+	3,  // 17: mcp.v1.GetCallGraphResponse.edges:type_name -> mcp.v1.Edge
+	0,  // 18: mcp.v1.MeshGraphEvent.type:type_name -> mcp.v1.MeshGraphEvent.Type
+	6,  // 19: mcp.v1.MeshGraphEvent.snapshot:type_name -> mcp.v1.MeshGraph
+	1,  // 20: mcp.v1.MeshGraphEvent.service:type_name -> mcp.v1.Service
+	3,  // 21: mcp.v1.MeshGraphEvent.edge:type_name -> mcp.v1.Edge
+	4,  // 22: mcp.v1.MeshGraphEvent.policy:type_name -> mcp.v1.AuthPolicy
+	2,  // 23: mcp.v1.MeshGraphEvent.workload:type_name -> mcp.v1.Workload
+	5,  // 24: mcp.v1.MeshGraphEvent.resource:type_name -> mcp.v1.Resource
+	24, // 25: mcp.v1.ApplyAuthorizationPolicyResponse.errors:type_name -> mcp.v1.FieldError
+	24, // 26: mcp.v1.ApplyAuthorizationPolicyResponse.conflicts:type_name -> mcp.v1.FieldError
+	31, // 27: mcp.v1.ApplyRouteRequest.annotations:type_name -> mcp.v1.ApplyRouteRequest.AnnotationsEntry
+	24, // 28: mcp.v1.ApplyRouteResponse.errors:type_name -> mcp.v1.FieldError
+	24, // 29: mcp.v1.ApplyRouteResponse.conflicts:type_name -> mcp.v1.FieldError
+	1,  // 30: mcp.v1.MeshGraph.ServicesEntry.value:type_name -> mcp.v1.Service
+	4,  // 31: mcp.v1.MeshGraph.AuthPoliciesEntry.value:type_name -> mcp.v1.AuthPolicy
+	2,  // 32: mcp.v1.MeshGraph.WorkloadsEntry.value:type_name -> mcp.v1.Workload
+	5,  // 33: mcp.v1.MeshGraph.ResourcesEntry.value:type_name -> mcp.v1.Resource
+	7,  // 34: mcp.v1.MeshGraph.SourcesEntry.value:type_name -> mcp.v1.SourceStatus
+	10, // 35: mcp.v1.MeshContext.GetMeshGraph:input_type -> mcp.v1.GetMeshGraphRequest
+	16, // 36: mcp.v1.MeshContext.WatchMeshGraph:input_type -> mcp.v1.WatchMeshGraphRequest
+	14, // 37: mcp.v1.MeshContext.GetCallGraph:input_type -> mcp.v1.GetCallGraphRequest
+	18, // 38: mcp.v1.MeshContext.ApplyAuthorizationPolicy:input_type -> mcp.v1.ApplyAuthorizationPolicyRequest
+	20, // 39: mcp.v1.MeshContext.DeleteAuthorizationPolicy:input_type -> mcp.v1.DeleteAuthorizationPolicyRequest
+	22, // 40: mcp.v1.MeshContext.ApplyHTTPRoute:input_type -> mcp.v1.ApplyRouteRequest
+	22, // 41: mcp.v1.MeshContext.ApplyGRPCRoute:input_type -> mcp.v1.ApplyRouteRequest
+	12, // 42: mcp.v1.MeshContext.GetStatus:input_type -> mcp.v1.GetStatusRequest
+	11, // 43: mcp.v1.MeshContext.GetMeshGraph:output_type -> mcp.v1.GetMeshGraphResponse
+	17, // 44: mcp.v1.MeshContext.WatchMeshGraph:output_type -> mcp.v1.MeshGraphEvent
+	15, // 45: mcp.v1.MeshContext.GetCallGraph:output_type -> mcp.v1.GetCallGraphResponse
+	19, // 46: mcp.v1.MeshContext.ApplyAuthorizationPolicy:output_type -> mcp.v1.ApplyAuthorizationPolicyResponse
+	21, // 47: mcp.v1.MeshContext.DeleteAuthorizationPolicy:output_type -> mcp.v1.DeleteAuthorizationPolicyResponse
+	23, // 48: mcp.v1.MeshContext.ApplyHTTPRoute:output_type -> mcp.v1.ApplyRouteResponse
+	23, // 49: mcp.v1.MeshContext.ApplyGRPCRoute:output_type -> mcp.v1.ApplyRouteResponse
+	13, // 50: mcp.v1.MeshContext.GetStatus:output_type -> mcp.v1.GetStatusResponse
+	43, // [43:51] is the sub-list for method output_type
+	35, // [35:43] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_mcp_proto_init() }
@@ -278,13 +2267,14 @@ func file_mcp_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mcp_proto_rawDesc), len(file_mcp_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      1,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_mcp_proto_goTypes,
 		DependencyIndexes: file_mcp_proto_depIdxs,
+		EnumInfos:         file_mcp_proto_enumTypes,
 		MessageInfos:      file_mcp_proto_msgTypes,
 	}.Build()
 	File_mcp_proto = out.File
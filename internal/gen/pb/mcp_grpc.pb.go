@@ -21,8 +21,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MeshContext_GetMeshGraph_FullMethodName             = "/mcp.v1.MeshContext/GetMeshGraph"
-	MeshContext_ApplyAuthorizationPolicy_FullMethodName = "/mcp.v1.MeshContext/ApplyAuthorizationPolicy"
+	MeshContext_GetMeshGraph_FullMethodName              = "/mcp.v1.MeshContext/GetMeshGraph"
+	MeshContext_WatchMeshGraph_FullMethodName            = "/mcp.v1.MeshContext/WatchMeshGraph"
+	MeshContext_GetCallGraph_FullMethodName              = "/mcp.v1.MeshContext/GetCallGraph"
+	MeshContext_ApplyAuthorizationPolicy_FullMethodName  = "/mcp.v1.MeshContext/ApplyAuthorizationPolicy"
+	MeshContext_DeleteAuthorizationPolicy_FullMethodName = "/mcp.v1.MeshContext/DeleteAuthorizationPolicy"
+	MeshContext_ApplyHTTPRoute_FullMethodName            = "/mcp.v1.MeshContext/ApplyHTTPRoute"
+	MeshContext_ApplyGRPCRoute_FullMethodName            = "/mcp.v1.MeshContext/ApplyGRPCRoute"
+	MeshContext_GetStatus_FullMethodName                 = "/mcp.v1.MeshContext/GetStatus"
 )
 
 // MeshContextClient is the client API for MeshContext service.
@@ -32,7 +38,20 @@ const (
 // Placeholder service definition
 type MeshContextClient interface {
 	GetMeshGraph(ctx context.Context, in *GetMeshGraphRequest, opts ...grpc.CallOption) (*GetMeshGraphResponse, error)
+	// WatchMeshGraph streams a full snapshot followed by incremental changes
+	WatchMeshGraph(ctx context.Context, in *WatchMeshGraphRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MeshGraphEvent], error)
+	// GetCallGraph lists call edges, e.g. the non-mTLS'd calls in a namespace
+	GetCallGraph(ctx context.Context, in *GetCallGraphRequest, opts ...grpc.CallOption) (*GetCallGraphResponse, error)
 	ApplyAuthorizationPolicy(ctx context.Context, in *ApplyAuthorizationPolicyRequest, opts ...grpc.CallOption) (*ApplyAuthorizationPolicyResponse, error)
+	// DeleteAuthorizationPolicy removes a policy created through this API;
+	// policies created by other means are never deleted
+	DeleteAuthorizationPolicy(ctx context.Context, in *DeleteAuthorizationPolicyRequest, opts ...grpc.CallOption) (*DeleteAuthorizationPolicyResponse, error)
+	// ApplyHTTPRoute and ApplyGRPCRoute create or update a Gateway API route
+	// attached to a Linkerd Server or a Service
+	ApplyHTTPRoute(ctx context.Context, in *ApplyRouteRequest, opts ...grpc.CallOption) (*ApplyRouteResponse, error)
+	ApplyGRPCRoute(ctx context.Context, in *ApplyRouteRequest, opts ...grpc.CallOption) (*ApplyRouteResponse, error)
+	// GetStatus reports how current the server's graph is, per data source
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error)
 }
 
 type meshContextClient struct {
@@ -53,6 +72,35 @@ func (c *meshContextClient) GetMeshGraph(ctx context.Context, in *GetMeshGraphRe
 	return out, nil
 }
 
+func (c *meshContextClient) WatchMeshGraph(ctx context.Context, in *WatchMeshGraphRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MeshGraphEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MeshContext_ServiceDesc.Streams[0], MeshContext_WatchMeshGraph_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMeshGraphRequest, MeshGraphEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MeshContext_WatchMeshGraphClient = grpc.ServerStreamingClient[MeshGraphEvent]
+
+func (c *meshContextClient) GetCallGraph(ctx context.Context, in *GetCallGraphRequest, opts ...grpc.CallOption) (*GetCallGraphResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCallGraphResponse)
+	err := c.cc.Invoke(ctx, MeshContext_GetCallGraph_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *meshContextClient) ApplyAuthorizationPolicy(ctx context.Context, in *ApplyAuthorizationPolicyRequest, opts ...grpc.CallOption) (*ApplyAuthorizationPolicyResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApplyAuthorizationPolicyResponse)
@@ -63,6 +111,46 @@ func (c *meshContextClient) ApplyAuthorizationPolicy(ctx context.Context, in *Ap
 	return out, nil
 }
 
+func (c *meshContextClient) DeleteAuthorizationPolicy(ctx context.Context, in *DeleteAuthorizationPolicyRequest, opts ...grpc.CallOption) (*DeleteAuthorizationPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteAuthorizationPolicyResponse)
+	err := c.cc.Invoke(ctx, MeshContext_DeleteAuthorizationPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshContextClient) ApplyHTTPRoute(ctx context.Context, in *ApplyRouteRequest, opts ...grpc.CallOption) (*ApplyRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyRouteResponse)
+	err := c.cc.Invoke(ctx, MeshContext_ApplyHTTPRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshContextClient) ApplyGRPCRoute(ctx context.Context, in *ApplyRouteRequest, opts ...grpc.CallOption) (*ApplyRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyRouteResponse)
+	err := c.cc.Invoke(ctx, MeshContext_ApplyGRPCRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *meshContextClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*GetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatusResponse)
+	err := c.cc.Invoke(ctx, MeshContext_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MeshContextServer is the server API for MeshContext service.
 // All implementations must embed UnimplementedMeshContextServer
 // for forward compatibility.
@@ -70,7 +158,20 @@ func (c *meshContextClient) ApplyAuthorizationPolicy(ctx context.Context, in *Ap
 // Placeholder service definition
 type MeshContextServer interface {
 	GetMeshGraph(context.Context, *GetMeshGraphRequest) (*GetMeshGraphResponse, error)
+	// WatchMeshGraph streams a full snapshot followed by incremental changes
+	WatchMeshGraph(*WatchMeshGraphRequest, grpc.ServerStreamingServer[MeshGraphEvent]) error
+	// GetCallGraph lists call edges, e.g. the non-mTLS'd calls in a namespace
+	GetCallGraph(context.Context, *GetCallGraphRequest) (*GetCallGraphResponse, error)
 	ApplyAuthorizationPolicy(context.Context, *ApplyAuthorizationPolicyRequest) (*ApplyAuthorizationPolicyResponse, error)
+	// DeleteAuthorizationPolicy removes a policy created through this API;
+	// policies created by other means are never deleted
+	DeleteAuthorizationPolicy(context.Context, *DeleteAuthorizationPolicyRequest) (*DeleteAuthorizationPolicyResponse, error)
+	// ApplyHTTPRoute and ApplyGRPCRoute create or update a Gateway API route
+	// attached to a Linkerd Server or a Service
+	ApplyHTTPRoute(context.Context, *ApplyRouteRequest) (*ApplyRouteResponse, error)
+	ApplyGRPCRoute(context.Context, *ApplyRouteRequest) (*ApplyRouteResponse, error)
+	// GetStatus reports how current the server's graph is, per data source
+	GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error)
 	mustEmbedUnimplementedMeshContextServer()
 }
 
@@ -84,9 +185,27 @@ type UnimplementedMeshContextServer struct{}
 func (UnimplementedMeshContextServer) GetMeshGraph(context.Context, *GetMeshGraphRequest) (*GetMeshGraphResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMeshGraph not implemented")
 }
+func (UnimplementedMeshContextServer) WatchMeshGraph(*WatchMeshGraphRequest, grpc.ServerStreamingServer[MeshGraphEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMeshGraph not implemented")
+}
+func (UnimplementedMeshContextServer) GetCallGraph(context.Context, *GetCallGraphRequest) (*GetCallGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCallGraph not implemented")
+}
 func (UnimplementedMeshContextServer) ApplyAuthorizationPolicy(context.Context, *ApplyAuthorizationPolicyRequest) (*ApplyAuthorizationPolicyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyAuthorizationPolicy not implemented")
 }
+func (UnimplementedMeshContextServer) DeleteAuthorizationPolicy(context.Context, *DeleteAuthorizationPolicyRequest) (*DeleteAuthorizationPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthorizationPolicy not implemented")
+}
+func (UnimplementedMeshContextServer) ApplyHTTPRoute(context.Context, *ApplyRouteRequest) (*ApplyRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyHTTPRoute not implemented")
+}
+func (UnimplementedMeshContextServer) ApplyGRPCRoute(context.Context, *ApplyRouteRequest) (*ApplyRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyGRPCRoute not implemented")
+}
+func (UnimplementedMeshContextServer) GetStatus(context.Context, *GetStatusRequest) (*GetStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
 func (UnimplementedMeshContextServer) mustEmbedUnimplementedMeshContextServer() {}
 func (UnimplementedMeshContextServer) testEmbeddedByValue()                     {}
 
@@ -126,6 +245,35 @@ func _MeshContext_GetMeshGraph_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshContext_WatchMeshGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMeshGraphRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MeshContextServer).WatchMeshGraph(m, &grpc.GenericServerStream[WatchMeshGraphRequest, MeshGraphEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MeshContext_WatchMeshGraphServer = grpc.ServerStreamingServer[MeshGraphEvent]
+
+func _MeshContext_GetCallGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCallGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshContextServer).GetCallGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshContext_GetCallGraph_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshContextServer).GetCallGraph(ctx, req.(*GetCallGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MeshContext_ApplyAuthorizationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ApplyAuthorizationPolicyRequest)
 	if err := dec(in); err != nil {
@@ -144,6 +292,78 @@ func _MeshContext_ApplyAuthorizationPolicy_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MeshContext_DeleteAuthorizationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAuthorizationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshContextServer).DeleteAuthorizationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshContext_DeleteAuthorizationPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshContextServer).DeleteAuthorizationPolicy(ctx, req.(*DeleteAuthorizationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshContext_ApplyHTTPRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshContextServer).ApplyHTTPRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshContext_ApplyHTTPRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshContextServer).ApplyHTTPRoute(ctx, req.(*ApplyRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshContext_ApplyGRPCRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshContextServer).ApplyGRPCRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshContext_ApplyGRPCRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshContextServer).ApplyGRPCRoute(ctx, req.(*ApplyRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MeshContext_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MeshContextServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MeshContext_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MeshContextServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MeshContext_ServiceDesc is the grpc.ServiceDesc for MeshContext service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -155,11 +375,37 @@ var MeshContext_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMeshGraph",
 			Handler:    _MeshContext_GetMeshGraph_Handler,
 		},
+		{
+			MethodName: "GetCallGraph",
+			Handler:    _MeshContext_GetCallGraph_Handler,
+		},
 		{
 			MethodName: "ApplyAuthorizationPolicy",
 			Handler:    _MeshContext_ApplyAuthorizationPolicy_Handler,
 		},
+		{
+			MethodName: "DeleteAuthorizationPolicy",
+			Handler:    _MeshContext_DeleteAuthorizationPolicy_Handler,
+		},
+		{
+			MethodName: "ApplyHTTPRoute",
+			Handler:    _MeshContext_ApplyHTTPRoute_Handler,
+		},
+		{
+			MethodName: "ApplyGRPCRoute",
+			Handler:    _MeshContext_ApplyGRPCRoute_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _MeshContext_GetStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMeshGraph",
+			Handler:       _MeshContext_WatchMeshGraph_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "mcp.proto",
 }
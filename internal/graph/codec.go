@@ -0,0 +1,217 @@
+// internal/graph/codec.go
+
+package graph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+)
+
+// SnapshotSchema is the version of the snapshot header written by EncodeSnapshot
+const SnapshotSchema = 2
+
+// Snapshot body encodings
+const (
+	EncodingJSON  = "json"
+	EncodingProto = "proto"
+)
+
+// Snapshot body compressions
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// SnapshotFormat selects how EncodeSnapshot writes the graph
+type SnapshotFormat struct {
+	Encoding    string
+	Compression string
+}
+
+// DefaultSnapshotFormat is gzip-compressed JSON
+var DefaultSnapshotFormat = SnapshotFormat{Encoding: EncodingJSON, Compression: CompressionGzip}
+
+// ParseSnapshotFormat validates an encoding and compression, defaulting
+// whichever is empty
+func ParseSnapshotFormat(encoding, compression string) (SnapshotFormat, error) {
+	f := DefaultSnapshotFormat
+	switch encoding {
+	case "":
+	case EncodingJSON, EncodingProto:
+		f.Encoding = encoding
+	default:
+		return f, fmt.Errorf("unknown snapshot encoding %q", encoding)
+	}
+	switch compression {
+	case "":
+	case CompressionNone, CompressionGzip, CompressionZstd:
+		f.Compression = compression
+	default:
+		return f, fmt.Errorf("unknown snapshot compression %q", compression)
+	}
+	return f, nil
+}
+
+// snapshotHeader is the first line of an encoded snapshot. Being a JSON
+// object, it lets backends splice the assigned "seq" in front, like deltas.
+type snapshotHeader struct {
+	Revision
+	Schema      int    `json:"schema"`
+	Encoding    string `json:"encoding"`
+	Compression string `json:"compression"`
+}
+
+// EncodeSnapshot writes snap as a one-line JSON header, carrying the
+// revision and format, followed by the encoded and compressed graph
+func EncodeSnapshot(snap *Snapshot, format SnapshotFormat) ([]byte, error) {
+	header, err := json.Marshal(snapshotHeader{
+		Revision:    snap.Revision,
+		Schema:      SnapshotSchema,
+		Encoding:    format.Encoding,
+		Compression: format.Compression,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	switch format.Encoding {
+	case EncodingJSON:
+		body, err = json.Marshal(snap.Graph)
+	case EncodingProto:
+		var g *pb.MeshGraph
+		if g, err = ToProto(snap.Graph); err == nil {
+			body, err = proto.Marshal(g)
+		}
+	default:
+		err = fmt.Errorf("unknown snapshot encoding %q", format.Encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteByte('\n')
+	switch format.Compression {
+	case CompressionNone:
+		buf.Write(body)
+	case CompressionGzip:
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := zw.Write(body); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown snapshot compression %q", format.Compression)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeSnapshot parses a mesh:snapshot payload in any format written so far:
+// a header line and encoded body, a JSON Snapshot, or (from before deltas
+// were sequenced) a bare JSON graph, which decodes with Seq 0.
+func DecodeSnapshot(data []byte) (*Snapshot, error) {
+	// Compact JSON never holds a raw newline, so one ends a header
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return decodeEncodedSnapshot(data[:i], data[i+1:])
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+	if _, ok := probe["graph"]; !ok {
+		var g MeshGraph
+		if err := json.Unmarshal(data, &g); err != nil {
+			return nil, err
+		}
+		g.ensureMaps()
+		return &Snapshot{Graph: &g}, nil
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+	if snap.Graph == nil {
+		snap.Graph = &MeshGraph{}
+	}
+	snap.Graph.ensureMaps()
+	return &snap, nil
+}
+
+func decodeEncodedSnapshot(headerLine, body []byte) (*Snapshot, error) {
+	var header snapshotHeader
+	if err := json.Unmarshal(headerLine, &header); err != nil {
+		return nil, fmt.Errorf("invalid snapshot header: %w", err)
+	}
+	if header.Schema > SnapshotSchema {
+		return nil, fmt.Errorf("snapshot schema %d is newer than supported %d", header.Schema, SnapshotSchema)
+	}
+
+	var r io.Reader = bytes.NewReader(body)
+	switch header.Compression {
+	case CompressionNone:
+	case CompressionGzip:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot gzip: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	case CompressionZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot zstd: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, fmt.Errorf("unknown snapshot compression %q", header.Compression)
+	}
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing snapshot: %w", err)
+	}
+
+	snap := &Snapshot{Revision: header.Revision}
+	switch header.Encoding {
+	case EncodingJSON:
+		var g MeshGraph
+		if err := json.Unmarshal(raw, &g); err != nil {
+			return nil, fmt.Errorf("decoding snapshot: %w", err)
+		}
+		g.ensureMaps()
+		snap.Graph = &g
+	case EncodingProto:
+		var g pb.MeshGraph
+		if err := proto.Unmarshal(raw, &g); err != nil {
+			return nil, fmt.Errorf("decoding snapshot: %w", err)
+		}
+		snap.Graph = FromProto(&g)
+	default:
+		return nil, fmt.Errorf("unknown snapshot encoding %q", header.Encoding)
+	}
+	return snap, nil
+}
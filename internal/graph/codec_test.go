@@ -0,0 +1,85 @@
+// internal/graph/codec_test.go
+
+package graph
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSnapshotCodec_RoundTrip(t *testing.T) {
+	g := &MeshGraph{
+		Services: map[string]Service{"shop/web": {Name: "web", Namespace: "shop", Meshed: true, MeshedPods: 2, TotalPods: 2}},
+		Edges:    []Edge{{SrcNamespace: "shop", Src: "web", DstNamespace: "shop", Dst: "cart", RPS: 1.5, TLS: true}},
+		AuthPolicies: map[string]AuthPolicy{
+			"shop/allow": {Name: "allow", Namespace: "shop", Spec: map[string]interface{}{"targetRef": map[string]interface{}{"name": "web"}}},
+		},
+		Sources: map[string]SourceStatus{
+			SourcePrometheus: {Synced: true, LastSuccess: time.Date(2025, 6, 1, 11, 59, 45, 0, time.UTC), Error: "timeout"},
+		},
+	}
+	g.ensureMaps()
+	rev := Revision{Origin: "collector@test", Timestamp: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+	for _, encoding := range []string{EncodingJSON, EncodingProto} {
+		for _, compression := range []string{CompressionNone, CompressionGzip, CompressionZstd} {
+			data, err := EncodeSnapshot(&Snapshot{Revision: rev, Graph: g}, SnapshotFormat{Encoding: encoding, Compression: compression})
+			if err != nil {
+				t.Fatalf("%s/%s: %v", encoding, compression, err)
+			}
+			// Backends stamp the sequence number into the header
+			data = append([]byte(`{"seq":9,`), data[1:]...)
+
+			snap, err := DecodeSnapshot(data)
+			if err != nil {
+				t.Fatalf("%s/%s: %v", encoding, compression, err)
+			}
+			if snap.Seq != 9 || snap.Origin != rev.Origin || !snap.Timestamp.Equal(rev.Timestamp) {
+				t.Errorf("%s/%s: unexpected revision %+v", encoding, compression, snap.Revision)
+			}
+			if d := Diff(g, snap.Graph); len(d) != 0 {
+				t.Errorf("%s/%s: graph changed in round trip: %+v", encoding, compression, d)
+			}
+		}
+	}
+}
+
+func TestDecodeSnapshot_Errors(t *testing.T) {
+	data, err := EncodeSnapshot(&Snapshot{Graph: &MeshGraph{}}, DefaultSnapshotFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer := bytes.Replace(data, []byte(`"schema":2`), []byte(`"schema":3`), 1)
+	if _, err := DecodeSnapshot(newer); err == nil {
+		t.Errorf("expected a newer schema to be rejected")
+	}
+	if _, err := DecodeSnapshot(data[:len(data)-4]); err == nil {
+		t.Errorf("expected a truncated body to fail")
+	}
+	if _, err := ParseSnapshotFormat("yaml", ""); err == nil {
+		t.Errorf("expected an unknown encoding to be rejected")
+	}
+	if f, _ := ParseSnapshotFormat(EncodingProto, ""); f.Compression != CompressionGzip {
+		t.Errorf("expected gzip by default, got %+v", f)
+	}
+}
+
+func TestDecodeSnapshot_Legacy(t *testing.T) {
+	snap, err := DecodeSnapshot([]byte(`{"seq":7,"graph":{"Services":{"shop/web":{"Name":"web","Namespace":"shop"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Seq != 7 || len(snap.Graph.Services) != 1 {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+
+	// Snapshots from before sequencing are a bare graph
+	snap, err = DecodeSnapshot([]byte(`{"Services":{"web":{"Name":"web","Namespace":"shop"}},"Edges":[]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap.Seq != 0 || snap.Graph.Services["shop/web"].Name != "web" {
+		t.Errorf("expected legacy snapshot to decode, got %+v", snap.Graph)
+	}
+}
@@ -0,0 +1,206 @@
+// internal/graph/diff.go
+
+package graph
+
+import (
+	"maps"
+	"reflect"
+	"sort"
+)
+
+type ChangeType int
+
+const (
+	ServiceAdded ChangeType = iota + 1
+	ServiceUpdated
+	ServiceRemoved
+	EdgeAdded
+	EdgeUpdated
+	EdgeRemoved
+	PolicyApplied
+	PolicyRemoved
+	WorkloadAdded
+	WorkloadUpdated
+	WorkloadRemoved
+	ResourceApplied
+	ResourceRemoved
+	SourceUpdated
+)
+
+// Change is a single node/edge/policy level difference between two graphs.
+// Exactly one of Service, Edge, Policy, Workload, Resource or Source is set,
+// holding the new value (or the old one for removals).
+type Change struct {
+	Type     ChangeType
+	Key      string
+	Service  *Service
+	Edge     *Edge
+	Policy   *AuthPolicy
+	Workload *Workload
+	Resource *Resource
+	Source   *SourceStatus
+}
+
+// EdgeKey identifies an edge by its namespace-qualified endpoints
+func EdgeKey(e Edge) string {
+	return e.SrcNamespace + "/" + e.Src + "->" + e.DstNamespace + "/" + e.Dst
+}
+
+// Clone returns a deep copy of the graph, safe to mutate independently
+func (g *MeshGraph) Clone() *MeshGraph {
+	out := &MeshGraph{
+		Services:     make(map[string]Service, len(g.Services)),
+		Edges:        make([]Edge, len(g.Edges)),
+		AuthPolicies: make(map[string]AuthPolicy, len(g.AuthPolicies)),
+		Workloads:    make(map[string]Workload, len(g.Workloads)),
+		Resources:    make(map[string]Resource, len(g.Resources)),
+		Sources:      make(map[string]SourceStatus, len(g.Sources)),
+	}
+	for k, v := range g.Services {
+		out.Services[k] = v
+	}
+	for k, v := range g.Workloads {
+		v.Services = append([]string(nil), v.Services...)
+		out.Workloads[k] = v
+	}
+	copy(out.Edges, g.Edges)
+	for k, v := range g.AuthPolicies {
+		v.Spec = copyMap(v.Spec)
+		out.AuthPolicies[k] = v
+	}
+	for k, v := range g.Resources {
+		v.Spec = copyMap(v.Spec)
+		v.Annotations = maps.Clone(v.Annotations)
+		out.Resources[k] = v
+	}
+	for k, v := range g.Sources {
+		out.Sources[k] = v
+	}
+	return out
+}
+
+// Diff lists the changes that turn old into new, in a stable order
+// (services, edges, policies, workloads, resources, then sources, each sorted
+// by key). Sources are only ever updated, never removed.
+func Diff(old, new *MeshGraph) []Change {
+	var changes []Change
+
+	for _, key := range sortedKeys(old.Services, new.Services) {
+		before, hadBefore := old.Services[key]
+		after, hasAfter := new.Services[key]
+		switch {
+		case !hadBefore:
+			changes = append(changes, Change{Type: ServiceAdded, Key: key, Service: &after})
+		case !hasAfter:
+			changes = append(changes, Change{Type: ServiceRemoved, Key: key, Service: &before})
+		case before != after:
+			changes = append(changes, Change{Type: ServiceUpdated, Key: key, Service: &after})
+		}
+	}
+
+	oldEdges := make(map[string]Edge, len(old.Edges))
+	for _, e := range old.Edges {
+		oldEdges[EdgeKey(e)] = e
+	}
+	newEdges := make(map[string]Edge, len(new.Edges))
+	for _, e := range new.Edges {
+		newEdges[EdgeKey(e)] = e
+	}
+	for _, key := range sortedKeys(oldEdges, newEdges) {
+		before, hadBefore := oldEdges[key]
+		after, hasAfter := newEdges[key]
+		switch {
+		case !hadBefore:
+			changes = append(changes, Change{Type: EdgeAdded, Key: key, Edge: &after})
+		case !hasAfter:
+			changes = append(changes, Change{Type: EdgeRemoved, Key: key, Edge: &before})
+		case before != after:
+			changes = append(changes, Change{Type: EdgeUpdated, Key: key, Edge: &after})
+		}
+	}
+
+	for _, key := range sortedKeys(old.AuthPolicies, new.AuthPolicies) {
+		before, hadBefore := old.AuthPolicies[key]
+		after, hasAfter := new.AuthPolicies[key]
+		switch {
+		case !hasAfter:
+			changes = append(changes, Change{Type: PolicyRemoved, Key: key, Policy: &before})
+		case !hadBefore || !reflect.DeepEqual(before, after):
+			changes = append(changes, Change{Type: PolicyApplied, Key: key, Policy: &after})
+		}
+	}
+
+	for _, key := range sortedKeys(old.Workloads, new.Workloads) {
+		before, hadBefore := old.Workloads[key]
+		after, hasAfter := new.Workloads[key]
+		switch {
+		case !hadBefore:
+			changes = append(changes, Change{Type: WorkloadAdded, Key: key, Workload: &after})
+		case !hasAfter:
+			changes = append(changes, Change{Type: WorkloadRemoved, Key: key, Workload: &before})
+		case !reflect.DeepEqual(before, after):
+			changes = append(changes, Change{Type: WorkloadUpdated, Key: key, Workload: &after})
+		}
+	}
+
+	for _, key := range sortedKeys(old.Resources, new.Resources) {
+		before, hadBefore := old.Resources[key]
+		after, hasAfter := new.Resources[key]
+		switch {
+		case !hasAfter:
+			changes = append(changes, Change{Type: ResourceRemoved, Key: key, Resource: &before})
+		case !hadBefore || !reflect.DeepEqual(before, after):
+			changes = append(changes, Change{Type: ResourceApplied, Key: key, Resource: &after})
+		}
+	}
+
+	for _, key := range sortedKeys(old.Sources, new.Sources) {
+		before, hadBefore := old.Sources[key]
+		after, hasAfter := new.Sources[key]
+		if hasAfter && (!hadBefore || before != after) {
+			changes = append(changes, Change{Type: SourceUpdated, Key: key, Source: &after})
+		}
+	}
+
+	return changes
+}
+
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = copyValue(v)
+	}
+	return out
+}
+
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return copyMap(t)
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, e := range t {
+			out[i] = copyValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
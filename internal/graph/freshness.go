@@ -0,0 +1,97 @@
+// internal/graph/freshness.go
+
+package graph
+
+import (
+	"sort"
+	"time"
+)
+
+// Data sources feeding the graph, as keyed in MeshGraph.Sources
+const (
+	SourcePrometheus = "prometheus"
+	SourceServices   = "services"
+	SourcePods       = "pods"
+	SourceWorkloads  = "workloads"
+	SourcePolicies   = "policies"
+)
+
+// SourceFields lists the graph fields (as named in the API) each source feeds
+var SourceFields = map[string][]string{
+	SourcePrometheus: {"edges"},
+	SourceServices:   {"services"},
+	SourcePods:       {"services", "workloads"},
+	SourceWorkloads:  {"workloads"},
+	SourcePolicies:   {"auth_policies", "resources"},
+}
+
+// SourceStatus records how current one data source is. The collector
+// refreshes LastSuccess periodically while the source is healthy, so its age
+// tells readers how stale the data may be.
+type SourceStatus struct {
+	// Synced is true when the last check found the source delivering a full
+	// view (informer caches synced, the Prometheus query succeeded)
+	Synced bool
+	// LastSuccess is when the source was last confirmed current
+	LastSuccess time.Time
+	// Error is the last failure, "" when the last check succeeded
+	Error string
+}
+
+// SourceFreshness is a source's status judged at a point in time
+type SourceFreshness struct {
+	SourceStatus
+	Name  string
+	Stale bool
+}
+
+// Freshness summarises how current a graph is
+type Freshness struct {
+	// Stale is true when any source has not been confirmed recently
+	Stale bool
+	// Synced is true when every source is synced
+	Synced bool
+	// StaleFields lists the graph fields fed by a stale or unsynced source
+	StaleFields []string
+	// Sources holds every known source, sorted by name; ones the collector
+	// never reported count as stale and unsynced
+	Sources []SourceFreshness
+}
+
+// Freshness judges the graph's sources at now: a source is stale when it was
+// last confirmed more than staleAfter ago
+func (g *MeshGraph) Freshness(now time.Time, staleAfter time.Duration) Freshness {
+	out := Freshness{Synced: true}
+	names := make([]string, 0, len(SourceFields))
+	for name := range SourceFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make(map[string]bool)
+	for _, name := range names {
+		status := g.Sources[name]
+		f := SourceFreshness{
+			SourceStatus: status,
+			Name:         name,
+			Stale:        status.LastSuccess.IsZero() || now.Sub(status.LastSuccess) > staleAfter,
+		}
+		out.Sources = append(out.Sources, f)
+		if f.Stale {
+			out.Stale = true
+		}
+		if !f.Synced {
+			out.Synced = false
+		}
+		if f.Stale || !f.Synced {
+			for _, field := range SourceFields[name] {
+				fields[field] = true
+			}
+		}
+	}
+	for field := range fields {
+		out.StaleFields = append(out.StaleFields, field)
+	}
+	sort.Strings(out.StaleFields)
+	return out
+}
@@ -0,0 +1,38 @@
+// internal/graph/freshness_test.go
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFreshness(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := &MeshGraph{Sources: map[string]SourceStatus{
+		SourceServices:  {Synced: true, LastSuccess: now.Add(-10 * time.Second)},
+		SourcePods:      {Synced: true, LastSuccess: now.Add(-10 * time.Second)},
+		SourceWorkloads: {Synced: true, LastSuccess: now.Add(-10 * time.Second)},
+		SourcePolicies:  {Synced: false, LastSuccess: now.Add(-10 * time.Second)},
+		// Prometheus has been failing for a while
+		SourcePrometheus: {Synced: false, LastSuccess: now.Add(-5 * time.Minute), Error: "connection refused"},
+	}}
+
+	f := g.Freshness(now, time.Minute)
+	if !f.Stale || f.Synced {
+		t.Errorf("expected stale and unsynced, got stale=%t synced=%t", f.Stale, f.Synced)
+	}
+	if want := []string{"auth_policies", "edges", "resources"}; !reflect.DeepEqual(f.StaleFields, want) {
+		t.Errorf("expected stale fields %v, got %v", want, f.StaleFields)
+	}
+	if len(f.Sources) != len(SourceFields) || f.Sources[0].Name != SourcePods {
+		t.Errorf("expected every source sorted by name, got %+v", f.Sources)
+	}
+
+	// A graph without source information is neither fresh nor synced
+	empty := (&MeshGraph{}).Freshness(now, time.Minute)
+	if !empty.Stale || empty.Synced || len(empty.StaleFields) != 5 {
+		t.Errorf("expected an unreported graph to be stale, got %+v", empty)
+	}
+}
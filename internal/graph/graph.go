@@ -2,26 +2,183 @@
 
 package graph
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type Service struct {
 	Name      string
 	Namespace string
-	Meshed    bool
+	// Meshed is true when every pod selected by the service runs the proxy
+	Meshed bool
+	// MeshedPods and TotalPods count the pods matched by spec.selector
+	MeshedPods int
+	TotalPods  int
 }
 
+// Edge is observed traffic from a source workload to a destination service
+// (or workload, when the proxy could not resolve a service).
 type Edge struct {
-	Src string
-	Dst string
-	RPS float64
+	SrcNamespace string
+	Src          string
+	DstNamespace string
+	Dst          string
+	RPS          float64
+	// TLS is true when all observed requests on the edge were mTLS'd
 	TLS bool
+	// TLSRatio is the fraction (0-1) of requests sent over mTLS
+	TLSRatio float64
+	// SuccessRate is the fraction (0-1) of responses classified as success;
+	// 0 when no responses were observed
+	SuccessRate float64
+	// Response latency quantiles in milliseconds; 0 when unknown
+	LatencyP50Ms float64
+	LatencyP95Ms float64
+	LatencyP99Ms float64
+}
+
+// Workload is a pod controller (Deployment, StatefulSet or DaemonSet), the
+// granularity at which Linkerd metrics identify traffic endpoints.
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
+	// Replicas is the desired pod count, ReadyPods how many are ready
+	Replicas  int
+	ReadyPods int
+	// MeshedPods counts the workload's pods running the proxy
+	MeshedPods int
+	// ProxyVersion lists the proxy versions injected in its pods, comma-separated
+	ProxyVersion string
+	// Owner is the controller owning the workload itself ("Kind/name"), if any
+	Owner string
+	// Services holds the keys of services selecting the workload's pods
+	Services []string
 }
 
 type AuthPolicy struct {
-	Name string
-	Spec map[string]interface{}
+	Name      string
+	Namespace string
+	Spec      map[string]interface{}
+	// Managed is true for policies created through the API, the only ones
+	// it deletes
+	Managed bool
+}
+
+// Resource is a Linkerd policy or Gateway API route object observed in the
+// cluster (Server, ServerAuthorization, MeshTLSAuthentication,
+// NetworkAuthentication, HTTPRoute, GRPCRoute).
+type Resource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Spec      map[string]interface{}
+	// Annotations holds a route's Linkerd retry and timeout annotations
+	Annotations map[string]string
+	// Managed is true for resources created through the API
+	Managed bool
 }
 
 type MeshGraph struct {
+	// Services is keyed by ServiceKey (namespace/name)
 	Services     map[string]Service
 	Edges        []Edge
 	AuthPolicies map[string]AuthPolicy
+	// Workloads is keyed by WorkloadKey (namespace/kind/name)
+	Workloads map[string]Workload
+	// Resources is keyed by ResourceKey (namespace/kind/name)
+	Resources map[string]Resource
+	// Sources records the freshness of each data source, keyed by source name
+	Sources map[string]SourceStatus
+}
+
+// ServiceKey is the namespace-qualified key of a service in MeshGraph.Services
+func ServiceKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// WorkloadKey is the key of a workload in MeshGraph.Workloads
+func WorkloadKey(namespace, kind, name string) string {
+	return namespace + "/" + strings.ToLower(kind) + "/" + name
+}
+
+// PolicyKey is the key of an authorization policy in MeshGraph.AuthPolicies
+func PolicyKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// ResourceKey is the key of a policy or route resource in MeshGraph.Resources
+func ResourceKey(namespace, kind, name string) string {
+	return namespace + "/" + strings.ToLower(kind) + "/" + name
+}
+
+// UnmarshalJSON decodes a graph and migrates older snapshots: services used
+// to be keyed by bare name, and sections added since may be missing.
+func (g *MeshGraph) UnmarshalJSON(data []byte) error {
+	type plain MeshGraph
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*g = MeshGraph(decoded)
+	g.ensureMaps()
+	g.migrateServiceKeys()
+	return nil
+}
+
+func (g *MeshGraph) ensureMaps() {
+	if g.Services == nil {
+		g.Services = make(map[string]Service)
+	}
+	if g.AuthPolicies == nil {
+		g.AuthPolicies = make(map[string]AuthPolicy)
+	}
+	if g.Workloads == nil {
+		g.Workloads = make(map[string]Workload)
+	}
+	if g.Resources == nil {
+		g.Resources = make(map[string]Resource)
+	}
+	if g.Sources == nil {
+		g.Sources = make(map[string]SourceStatus)
+	}
+}
+
+func (g *MeshGraph) migrateServiceKeys() {
+	for key, svc := range g.Services {
+		if strings.Contains(key, "/") || svc.Namespace == "" {
+			continue
+		}
+		delete(g.Services, key)
+		g.Services[ServiceKey(svc.Namespace, svc.Name)] = svc
+	}
+}
+
+// EdgeFilter selects edges; zero values match everything
+type EdgeFilter struct {
+	// Namespace matches edges with either end in the namespace
+	Namespace string
+	// Source matches edges whose source workload has this name
+	Source string
+	// NonTLSOnly keeps only edges that are not fully mTLS'd
+	NonTLSOnly bool
+}
+
+// FilterEdges returns the edges matching f
+func (g *MeshGraph) FilterEdges(f EdgeFilter) []Edge {
+	var out []Edge
+	for _, e := range g.Edges {
+		if f.Namespace != "" && e.SrcNamespace != f.Namespace && e.DstNamespace != f.Namespace {
+			continue
+		}
+		if f.Source != "" && e.Src != f.Source {
+			continue
+		}
+		if f.NonTLSOnly && e.TLS {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
 }
@@ -3,6 +3,7 @@
 package graph
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -11,6 +12,8 @@ func TestMeshGraph_AddService(t *testing.T) {
 		Services:     make(map[string]Service),
 		Edges:        []Edge{},
 		AuthPolicies: make(map[string]AuthPolicy),
+		Workloads:    make(map[string]Workload),
+		Resources:    make(map[string]Resource),
 	}
 
 	svc := Service{
@@ -19,12 +22,121 @@ func TestMeshGraph_AddService(t *testing.T) {
 		Meshed:    true,
 	}
 
-	g.Services[svc.Name] = svc
+	g.Services[ServiceKey(svc.Namespace, svc.Name)] = svc
+	g.Services[ServiceKey("staging", svc.Name)] = Service{Name: svc.Name, Namespace: "staging"}
 
-	if len(g.Services) != 1 {
-		t.Fatalf("expected 1 service, got %d", len(g.Services))
+	if len(g.Services) != 2 {
+		t.Fatalf("expected 2 services, got %d", len(g.Services))
 	}
-	if g.Services["demo-app"].Name != "demo-app" {
-		t.Errorf("expected service name 'demo-app', got %s", g.Services["demo-app"].Name)
+	if g.Services["default/demo-app"].Name != "demo-app" {
+		t.Errorf("expected service name 'demo-app', got %s", g.Services["default/demo-app"].Name)
+	}
+	if !g.Services["default/demo-app"].Meshed || g.Services["staging/demo-app"].Meshed {
+		t.Errorf("expected same-named services in different namespaces to stay distinct")
+	}
+}
+
+func TestMeshGraph_UnmarshalLegacySnapshot(t *testing.T) {
+	legacy := `{"Services":{"web":{"Name":"web","Namespace":"shop","Meshed":true},"staging/web":{"Name":"web","Namespace":"staging"}},"Edges":[],"AuthPolicies":{}}`
+
+	var g MeshGraph
+	if err := json.Unmarshal([]byte(legacy), &g); err != nil {
+		t.Fatalf("failed to unmarshal legacy snapshot: %v", err)
+	}
+	if _, ok := g.Services["web"]; ok {
+		t.Errorf("expected bare-name key to be migrated")
+	}
+	if !g.Services["shop/web"].Meshed {
+		t.Errorf("expected shop/web after migration, got %+v", g.Services)
+	}
+	if _, ok := g.Services["staging/web"]; !ok {
+		t.Errorf("expected already-qualified key to be kept, got %+v", g.Services)
+	}
+}
+
+func TestMeshGraph_ProtoRoundTrip(t *testing.T) {
+	g := &MeshGraph{
+		Services: map[string]Service{
+			"default/service-a": {Name: "service-a", Namespace: "default", Meshed: true},
+		},
+		Edges: []Edge{{Src: "service-a", Dst: "service-b", RPS: 2.5, TLS: true}},
+		AuthPolicies: map[string]AuthPolicy{
+			"default/allow-a": {Name: "allow-a", Spec: map[string]interface{}{
+				"targetRef": map[string]interface{}{"kind": "Server", "name": "service-b"},
+			}},
+		},
+	}
+
+	p, err := ToProto(g)
+	if err != nil {
+		t.Fatalf("ToProto failed: %v", err)
+	}
+	back := FromProto(p)
+
+	if !back.Services["default/service-a"].Meshed {
+		t.Errorf("expected service-a to stay meshed")
+	}
+	if len(back.Edges) != 1 || back.Edges[0].RPS != 2.5 || !back.Edges[0].TLS {
+		t.Errorf("unexpected edges after round trip: %+v", back.Edges)
+	}
+	target, _ := back.AuthPolicies["default/allow-a"].Spec["targetRef"].(map[string]interface{})
+	if target["name"] != "service-b" {
+		t.Errorf("expected policy spec to survive round trip, got %+v", back.AuthPolicies)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := &MeshGraph{
+		Services: map[string]Service{
+			"default/a": {Name: "a", Namespace: "default"},
+			"default/b": {Name: "b", Namespace: "default"},
+		},
+		Edges:        []Edge{{Src: "a", Dst: "b", RPS: 1}},
+		AuthPolicies: map[string]AuthPolicy{},
+	}
+	new := old.Clone()
+	delete(new.Services, "default/b")
+	new.Services["default/c"] = Service{Name: "c", Namespace: "default", Meshed: true}
+	new.Edges[0].RPS = 3
+	new.AuthPolicies["default/allow"] = AuthPolicy{Name: "allow", Spec: map[string]interface{}{}}
+	new.Resources[ResourceKey("default", "Server", "b-http")] = Resource{Kind: "Server", Namespace: "default", Name: "b-http"}
+
+	changes := Diff(old, new)
+	want := []ChangeType{ServiceRemoved, ServiceAdded, EdgeUpdated, PolicyApplied, ResourceApplied}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i, c := range changes {
+		if c.Type != want[i] {
+			t.Errorf("change %d: expected type %d, got %d (%s)", i, want[i], c.Type, c.Key)
+		}
+	}
+	if old.Edges[0].RPS != 1 {
+		t.Errorf("Clone must not share edges with the original")
+	}
+	if len(Diff(new, new.Clone())) != 0 {
+		t.Errorf("expected no changes between a graph and its clone")
+	}
+}
+
+func TestMeshGraph_FilterEdges(t *testing.T) {
+	g := &MeshGraph{Edges: []Edge{
+		{SrcNamespace: "shop", Src: "web", DstNamespace: "shop", Dst: "cart", TLS: true, TLSRatio: 1},
+		{SrcNamespace: "shop", Src: "web", DstNamespace: "auth", Dst: "login", TLSRatio: 0.5},
+		{SrcNamespace: "staging", Src: "web", DstNamespace: "staging", Dst: "cart"},
+	}}
+
+	if got := g.FilterEdges(EdgeFilter{Namespace: "shop"}); len(got) != 2 {
+		t.Errorf("expected 2 edges touching shop, got %+v", got)
+	}
+	got := g.FilterEdges(EdgeFilter{Namespace: "auth", NonTLSOnly: true})
+	if len(got) != 1 || got[0].Dst != "login" {
+		t.Errorf("expected the partially mTLS'd edge into auth, got %+v", got)
+	}
+	if got := g.FilterEdges(EdgeFilter{NonTLSOnly: true}); len(got) != 2 {
+		t.Errorf("expected 2 non-mTLS'd edges overall, got %+v", got)
+	}
+	if got := g.FilterEdges(EdgeFilter{Namespace: "shop", Source: "web"}); len(got) != 2 {
+		t.Errorf("expected 2 dependencies of web in shop, got %+v", got)
 	}
 }
@@ -0,0 +1,342 @@
+// internal/graph/patch.go
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PatchOp is a single RFC 6902 JSON-Patch operation
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Patch is an RFC 6902 JSON-Patch document against the JSON form of a MeshGraph
+type Patch []PatchOp
+
+// Revision identifies a published state of the graph. Seq comes from a
+// shared counter and increases by one per delta, so subscribers can detect
+// gaps and replicas can tell whether they hold the same graph.
+type Revision struct {
+	// Seq is assigned when the delta or snapshot is published
+	Seq uint64 `json:"seq,omitempty"`
+	// Origin identifies the publishing process, which skips its own deltas
+	Origin string `json:"origin,omitempty"`
+	// Timestamp is when the publisher produced it
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Delta is one message on mesh:delta
+type Delta struct {
+	Revision
+	Patch Patch `json:"patch"`
+}
+
+// Snapshot is the mesh:snapshot content: the graph as of Revision. See
+// EncodeSnapshot for its wire format.
+type Snapshot struct {
+	Revision
+	Graph *MeshGraph `json:"graph"`
+}
+
+// CreatePatch returns the operations turning old into new. Map entries are
+// patched individually; the edge list is replaced as a whole when it changes.
+// A nil old replaces the whole document.
+func CreatePatch(old, new *MeshGraph) (Patch, error) {
+	if old == nil {
+		op, err := valueOp("replace", "", new)
+		if err != nil {
+			return nil, err
+		}
+		return Patch{op}, nil
+	}
+	var patch Patch
+	edgesChanged := false
+	for _, c := range Diff(old, new) {
+		var section string
+		var value interface{}
+		switch {
+		case c.Edge != nil:
+			edgesChanged = true
+			continue
+		case c.Service != nil:
+			section, value = "Services", c.Service
+		case c.Policy != nil:
+			section, value = "AuthPolicies", c.Policy
+		case c.Workload != nil:
+			section, value = "Workloads", c.Workload
+		case c.Resource != nil:
+			section, value = "Resources", c.Resource
+		case c.Source != nil:
+			section, value = "Sources", c.Source
+		}
+		path := "/" + section + "/" + escapePointer(c.Key)
+		switch c.Type {
+		case ServiceRemoved, PolicyRemoved, WorkloadRemoved, ResourceRemoved:
+			patch = append(patch, PatchOp{Op: "remove", Path: path})
+		default:
+			op, err := valueOp("add", path, value)
+			if err != nil {
+				return nil, err
+			}
+			patch = append(patch, op)
+		}
+	}
+	if edgesChanged {
+		edges := new.Edges
+		if edges == nil {
+			edges = []Edge{}
+		}
+		op, err := valueOp("replace", "/Edges", edges)
+		if err != nil {
+			return nil, err
+		}
+		patch = append(patch, op)
+	}
+	return patch, nil
+}
+
+func valueOp(op, path string, value interface{}) (PatchOp, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return PatchOp{}, fmt.Errorf("%s %s: %w", op, path, err)
+	}
+	return PatchOp{Op: op, Path: path, Value: data}, nil
+}
+
+// ApplyPatch returns a copy of g with patch applied. The patch is atomic:
+// on error g is left as it was.
+func ApplyPatch(g *MeshGraph, patch Patch) (*MeshGraph, error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for i, op := range patch {
+		if doc, err = op.apply(doc); err != nil {
+			return nil, fmt.Errorf("patch op %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	if data, err = json.Marshal(doc); err != nil {
+		return nil, err
+	}
+	var out MeshGraph
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (op PatchOp) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		var value interface{}
+		if len(op.Value) == 0 {
+			return nil, fmt.Errorf("missing value")
+		}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		switch op.Op {
+		case "add":
+			return addValue(doc, path, value)
+		case "replace":
+			if _, err := getValue(doc, path); err != nil {
+				return nil, err
+			}
+			if len(path) == 0 {
+				return value, nil
+			}
+			if doc, err = removeValue(doc, path); err != nil {
+				return nil, err
+			}
+			return addValue(doc, path, value)
+		default:
+			current, err := getValue(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("test failed")
+			}
+			return doc, nil
+		}
+	case "remove":
+		return removeValue(doc, path)
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "move" {
+			if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+				return nil, fmt.Errorf("cannot move a value into itself")
+			}
+			if doc, err = removeValue(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = copyValue(value)
+		}
+		return addValue(doc, path, value)
+	default:
+		return nil, fmt.Errorf("unsupported op %q", op.Op)
+	}
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			child, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			doc = child
+		case []interface{}:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("cannot descend into %q", token)
+		}
+	}
+	return doc, nil
+}
+
+// mutate calls fn with the parent of path and its last token, storing the
+// (possibly reallocated) parent back into the document
+func mutate(doc interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("no member %q", path[0])
+		}
+		updated, err := mutate(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[path[0]] = updated
+		return node, nil
+	case []interface{}:
+		i, err := arrayIndex(path[0], len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := mutate(node[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("cannot descend into %q", path[0])
+	}
+}
+
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return mutate(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			if token == "-" {
+				return append(node, value), nil
+			}
+			i, err := arrayIndex(token, len(node))
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar", token)
+		}
+	})
+}
+
+func removeValue(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the document root")
+	}
+	return mutate(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := node[token]; !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			return append(node[:i], node[i+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar", token)
+		}
+	})
+}
+
+// arrayIndex parses an array index token, allowing values up to max
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return i, nil
+}
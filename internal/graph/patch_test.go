@@ -0,0 +1,109 @@
+// internal/graph/patch_test.go
+
+package graph
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCreatePatch_RoundTrip(t *testing.T) {
+	old := NewStore().Snapshot()
+	old.Services["shop/web"] = Service{Name: "web", Namespace: "shop"}
+	old.Services["shop/gone"] = Service{Name: "gone", Namespace: "shop"}
+	old.Edges = []Edge{{SrcNamespace: "shop", Src: "web", DstNamespace: "shop", Dst: "cart", RPS: 1}}
+
+	new := old.Clone()
+	new.Services["shop/web"] = Service{Name: "web", Namespace: "shop", Meshed: true, MeshedPods: 2, TotalPods: 2}
+	delete(new.Services, "shop/gone")
+	new.Edges[0].RPS = 2.5
+	new.AuthPolicies["shop/allow~web"] = AuthPolicy{Name: "allow~web", Namespace: "shop", Spec: map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server"}}}
+	new.Resources[ResourceKey("shop", "HTTPRoute", "web")] = Resource{Kind: "HTTPRoute", Namespace: "shop", Name: "web"}
+
+	patch, err := CreatePatch(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := map[string]string{}
+	for _, op := range patch {
+		paths[op.Path] = op.Op
+	}
+	want := map[string]string{
+		"/Services/shop~1web":             "add",
+		"/Services/shop~1gone":            "remove",
+		"/AuthPolicies/shop~1allow~0web":  "add",
+		"/Resources/shop~1httproute~1web": "add",
+		"/Edges":                          "replace",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("unexpected patch ops: %v", paths)
+	}
+
+	got, err := ApplyPatch(old, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes := Diff(new, got); len(changes) != 0 {
+		t.Errorf("patched graph differs from target: %+v", changes)
+	}
+	if len(old.Services) != 2 {
+		t.Errorf("ApplyPatch must not modify its input")
+	}
+
+	// A nil base replaces the whole document
+	patch, err = CreatePatch(nil, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err = ApplyPatch(old, patch); err != nil || len(Diff(new, got)) != 0 {
+		t.Errorf("expected full replace to reproduce target, err=%v", err)
+	}
+}
+
+func TestApplyPatch_Operations(t *testing.T) {
+	g := NewStore().Snapshot()
+	g.Edges = []Edge{{Src: "a", Dst: "b"}}
+	g.AuthPolicies["default/allow"] = AuthPolicy{Name: "allow", Namespace: "default", Spec: map[string]interface{}{"ports": []interface{}{"http"}}}
+
+	var patch Patch
+	if err := json.Unmarshal([]byte(`[
+		{"op":"test","path":"/AuthPolicies/default~1allow/Name","value":"allow"},
+		{"op":"add","path":"/AuthPolicies/default~1allow/Spec/ports/0","value":"grpc"},
+		{"op":"add","path":"/AuthPolicies/default~1allow/Spec/ports/-","value":"admin"},
+		{"op":"copy","from":"/Edges/0","path":"/Edges/-"},
+		{"op":"replace","path":"/Edges/1/Src","value":"c"},
+		{"op":"move","from":"/AuthPolicies/default~1allow","path":"/AuthPolicies/default~1renamed"}
+	]`), &patch); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ApplyPatch(g, patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Edges) != 2 || got.Edges[0].Src != "a" || got.Edges[1].Src != "c" {
+		t.Errorf("unexpected edges: %+v", got.Edges)
+	}
+	policy, ok := got.AuthPolicies["default/renamed"]
+	if !ok || len(got.AuthPolicies) != 1 {
+		t.Fatalf("expected policy to be moved, got %+v", got.AuthPolicies)
+	}
+	if ports := policy.Spec["ports"]; !reflect.DeepEqual(ports, []interface{}{"grpc", "http", "admin"}) {
+		t.Errorf("unexpected ports: %v", ports)
+	}
+
+	for _, bad := range []string{
+		`[{"op":"remove","path":"/Services/missing"}]`,
+		`[{"op":"test","path":"/Edges/0/Src","value":"z"}]`,
+		`[{"op":"replace","path":"/Edges/5","value":{}}]`,
+		`[{"op":"frobnicate","path":"/Edges"}]`,
+	} {
+		var p Patch
+		if err := json.Unmarshal([]byte(bad), &p); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ApplyPatch(g, p); err == nil {
+			t.Errorf("expected %s to fail", bad)
+		}
+	}
+}
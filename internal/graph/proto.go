@@ -0,0 +1,232 @@
+// internal/graph/proto.go
+
+package graph
+
+import (
+	"fmt"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto converts the mesh graph into its typed protobuf form
+func ToProto(g *MeshGraph) (*pb.MeshGraph, error) {
+	out := &pb.MeshGraph{
+		Services:     make(map[string]*pb.Service, len(g.Services)),
+		Edges:        make([]*pb.Edge, 0, len(g.Edges)),
+		AuthPolicies: make(map[string]*pb.AuthPolicy, len(g.AuthPolicies)),
+		Workloads:    make(map[string]*pb.Workload, len(g.Workloads)),
+		Resources:    make(map[string]*pb.Resource, len(g.Resources)),
+		Sources:      make(map[string]*pb.SourceStatus, len(g.Sources)),
+	}
+	for key, svc := range g.Services {
+		out.Services[key] = ServiceToProto(svc)
+	}
+	for key, w := range g.Workloads {
+		out.Workloads[key] = WorkloadToProto(w)
+	}
+	for _, e := range g.Edges {
+		out.Edges = append(out.Edges, EdgeToProto(e))
+	}
+	for key, policy := range g.AuthPolicies {
+		p, err := PolicyToProto(policy)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: %w", key, err)
+		}
+		out.AuthPolicies[key] = p
+	}
+	for key, res := range g.Resources {
+		r, err := ResourceToProto(res)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", key, err)
+		}
+		out.Resources[key] = r
+	}
+	for name, status := range g.Sources {
+		out.Sources[name] = SourceToProto(SourceFreshness{SourceStatus: status, Name: name})
+	}
+	return out, nil
+}
+
+// ServiceToProto converts a single service
+func ServiceToProto(svc Service) *pb.Service {
+	return &pb.Service{
+		Name:       svc.Name,
+		Namespace:  svc.Namespace,
+		Meshed:     svc.Meshed,
+		MeshedPods: int32(svc.MeshedPods),
+		TotalPods:  int32(svc.TotalPods),
+	}
+}
+
+// WorkloadToProto converts a single workload
+func WorkloadToProto(w Workload) *pb.Workload {
+	return &pb.Workload{
+		Kind:         w.Kind,
+		Name:         w.Name,
+		Namespace:    w.Namespace,
+		Replicas:     int32(w.Replicas),
+		ReadyPods:    int32(w.ReadyPods),
+		MeshedPods:   int32(w.MeshedPods),
+		ProxyVersion: w.ProxyVersion,
+		Owner:        w.Owner,
+		Services:     w.Services,
+	}
+}
+
+// EdgeToProto converts a single edge
+func EdgeToProto(e Edge) *pb.Edge {
+	return &pb.Edge{
+		Src:          e.Src,
+		Dst:          e.Dst,
+		Rps:          e.RPS,
+		Tls:          e.TLS,
+		SrcNamespace: e.SrcNamespace,
+		DstNamespace: e.DstNamespace,
+		TlsRatio:     e.TLSRatio,
+		SuccessRate:  e.SuccessRate,
+		LatencyP50Ms: e.LatencyP50Ms,
+		LatencyP95Ms: e.LatencyP95Ms,
+		LatencyP99Ms: e.LatencyP99Ms,
+	}
+}
+
+// PolicyToProto converts a single authorization policy
+func PolicyToProto(policy AuthPolicy) (*pb.AuthPolicy, error) {
+	spec, err := structpb.NewStruct(policy.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	return &pb.AuthPolicy{
+		Name:      policy.Name,
+		Namespace: policy.Namespace,
+		Spec:      spec,
+		Managed:   policy.Managed,
+	}, nil
+}
+
+// ResourceToProto converts a single policy or route resource
+func ResourceToProto(res Resource) (*pb.Resource, error) {
+	spec, err := structpb.NewStruct(res.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	return &pb.Resource{
+		Kind:        res.Kind,
+		Namespace:   res.Namespace,
+		Name:        res.Name,
+		Spec:        spec,
+		Annotations: res.Annotations,
+		Managed:     res.Managed,
+	}, nil
+}
+
+// RevisionToProto converts a graph revision
+func RevisionToProto(rev Revision) *pb.Revision {
+	out := &pb.Revision{Seq: rev.Seq, Origin: rev.Origin}
+	if !rev.Timestamp.IsZero() {
+		out.Timestamp = timestamppb.New(rev.Timestamp)
+	}
+	return out
+}
+
+// SourceToProto converts a source's status; Stale is only meaningful when
+// it was judged by Freshness
+func SourceToProto(s SourceFreshness) *pb.SourceStatus {
+	out := &pb.SourceStatus{
+		Name:   s.Name,
+		Synced: s.Synced,
+		Error:  s.Error,
+		Stale:  s.Stale,
+		Fields: SourceFields[s.Name],
+	}
+	if !s.LastSuccess.IsZero() {
+		out.LastSuccess = timestamppb.New(s.LastSuccess)
+	}
+	return out
+}
+
+// FreshnessToProto converts a graph's freshness summary
+func FreshnessToProto(f Freshness) *pb.GraphStatus {
+	out := &pb.GraphStatus{Stale: f.Stale, Synced: f.Synced, StaleFields: f.StaleFields}
+	for _, s := range f.Sources {
+		out.Sources = append(out.Sources, SourceToProto(s))
+	}
+	return out
+}
+
+// FromProto converts a protobuf mesh graph back into the in-memory model
+func FromProto(in *pb.MeshGraph) *MeshGraph {
+	g := &MeshGraph{
+		Services:     make(map[string]Service, len(in.GetServices())),
+		Edges:        make([]Edge, 0, len(in.GetEdges())),
+		AuthPolicies: make(map[string]AuthPolicy, len(in.GetAuthPolicies())),
+		Workloads:    make(map[string]Workload, len(in.GetWorkloads())),
+		Resources:    make(map[string]Resource, len(in.GetResources())),
+		Sources:      make(map[string]SourceStatus, len(in.GetSources())),
+	}
+	for key, w := range in.GetWorkloads() {
+		g.Workloads[key] = Workload{
+			Kind:         w.GetKind(),
+			Name:         w.GetName(),
+			Namespace:    w.GetNamespace(),
+			Replicas:     int(w.GetReplicas()),
+			ReadyPods:    int(w.GetReadyPods()),
+			MeshedPods:   int(w.GetMeshedPods()),
+			ProxyVersion: w.GetProxyVersion(),
+			Owner:        w.GetOwner(),
+			Services:     w.GetServices(),
+		}
+	}
+	for key, svc := range in.GetServices() {
+		g.Services[key] = Service{
+			Name:       svc.GetName(),
+			Namespace:  svc.GetNamespace(),
+			Meshed:     svc.GetMeshed(),
+			MeshedPods: int(svc.GetMeshedPods()),
+			TotalPods:  int(svc.GetTotalPods()),
+		}
+	}
+	for _, e := range in.GetEdges() {
+		g.Edges = append(g.Edges, Edge{
+			SrcNamespace: e.GetSrcNamespace(),
+			Src:          e.GetSrc(),
+			DstNamespace: e.GetDstNamespace(),
+			Dst:          e.GetDst(),
+			RPS:          e.GetRps(),
+			TLS:          e.GetTls(),
+			TLSRatio:     e.GetTlsRatio(),
+			SuccessRate:  e.GetSuccessRate(),
+			LatencyP50Ms: e.GetLatencyP50Ms(),
+			LatencyP95Ms: e.GetLatencyP95Ms(),
+			LatencyP99Ms: e.GetLatencyP99Ms(),
+		})
+	}
+	for key, policy := range in.GetAuthPolicies() {
+		g.AuthPolicies[key] = AuthPolicy{
+			Name:      policy.GetName(),
+			Namespace: policy.GetNamespace(),
+			Spec:      policy.GetSpec().AsMap(),
+			Managed:   policy.GetManaged(),
+		}
+	}
+	for key, res := range in.GetResources() {
+		g.Resources[key] = Resource{
+			Kind:        res.GetKind(),
+			Namespace:   res.GetNamespace(),
+			Name:        res.GetName(),
+			Spec:        res.GetSpec().AsMap(),
+			Annotations: res.GetAnnotations(),
+			Managed:     res.GetManaged(),
+		}
+	}
+	for name, s := range in.GetSources() {
+		status := SourceStatus{Synced: s.GetSynced(), Error: s.GetError()}
+		if s.GetLastSuccess() != nil {
+			status.LastSuccess = s.GetLastSuccess().AsTime()
+		}
+		g.Sources[name] = status
+	}
+	return g
+}
@@ -0,0 +1,282 @@
+// internal/graph/store.go
+
+package graph
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Store is a concurrency-safe mesh graph. All mutations go through its
+// methods; reads get deep copies (Snapshot, Policies) or a read-locked view.
+type Store struct {
+	mu        sync.RWMutex
+	g         *MeshGraph
+	rev       Revision
+	observers []func([]Change)
+	// origin, once set by Sequence, stamps the revision of every change
+	origin string
+}
+
+// NewStore returns a store holding an empty graph
+func NewStore() *Store {
+	g := &MeshGraph{Edges: []Edge{}}
+	g.ensureMaps()
+	return &Store{g: g}
+}
+
+// Observe registers fn to receive the changes made by every mutation, in
+// order. fn runs while the store is locked and must not call back into it.
+func (s *Store) Observe(fn func(changes []Change)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observers = append(s.observers, fn)
+}
+
+// Sequence makes every mutation that changes the graph advance its
+// revision by one, stamped with origin. It is for a store written directly,
+// e.g. by an embedded collector, where no deltas carry revisions.
+func (s *Store) Sequence(origin string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.origin = origin
+}
+
+// commit advances a sequenced store's revision and hands changes to the
+// observers; callers hold the write lock
+func (s *Store) commit(changes ...Change) {
+	if len(changes) == 0 {
+		return
+	}
+	if s.origin != "" {
+		s.rev = Revision{Seq: s.rev.Seq + 1, Origin: s.origin, Timestamp: time.Now().UTC()}
+	}
+	for _, fn := range s.observers {
+		fn(changes)
+	}
+}
+
+// Snapshot returns a deep copy of the graph
+func (s *Store) Snapshot() *MeshGraph {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Clone()
+}
+
+// VersionedSnapshot returns a deep copy of the graph with the revision it is at
+func (s *Store) VersionedSnapshot() (*MeshGraph, Revision) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Clone(), s.rev
+}
+
+// Revision returns the revision of the last snapshot or delta applied
+func (s *Store) Revision() Revision {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rev
+}
+
+// Advance records that the graph already reflects rev, e.g. for a delta this
+// process published itself
+func (s *Store) Advance(rev Revision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advance(rev)
+}
+
+// advance moves the revision to rev, never back; callers hold the write lock
+func (s *Store) advance(rev Revision) {
+	if rev.Seq >= s.rev.Seq {
+		s.rev = rev
+	}
+}
+
+// View calls fn with the graph under a read lock. fn must neither modify
+// the graph nor retain any part of it after returning.
+func (s *Store) View(fn func(g *MeshGraph)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.g)
+}
+
+// Policies returns a copy of the authorization policies
+func (s *Store) Policies() map[string]AuthPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]AuthPolicy, len(s.g.AuthPolicies))
+	for k, v := range s.g.AuthPolicies {
+		v.Spec = copyMap(v.Spec)
+		out[k] = v
+	}
+	return out
+}
+
+// Resource returns a copy of the policy or route resource with key
+func (s *Store) Resource(key string) (Resource, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	res, ok := s.g.Resources[key]
+	res.Spec = copyMap(res.Spec)
+	res.Annotations = maps.Clone(res.Annotations)
+	return res, ok
+}
+
+// Replace swaps in a copy of g wholesale, e.g. when hydrating from a
+// snapshot at rev
+func (s *Store) Replace(g *MeshGraph, rev Revision) {
+	next := g.Clone()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes := Diff(s.g, next)
+	s.g = next
+	s.advance(rev)
+	s.commit(changes...)
+}
+
+// ApplyPatch applies the JSON-Patch of a delta at rev atomically; on error
+// the graph is unchanged
+func (s *Store) ApplyPatch(patch Patch, rev Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next, err := ApplyPatch(s.g, patch)
+	if err != nil {
+		return err
+	}
+	changes := Diff(s.g, next)
+	s.g = next
+	s.advance(rev)
+	s.commit(changes...)
+	return nil
+}
+
+// UpsertService adds or updates a service
+func (s *Store) UpsertService(svc Service) {
+	key := ServiceKey(svc.Namespace, svc.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before, ok := s.g.Services[key]
+	s.g.Services[key] = svc
+	switch {
+	case !ok:
+		s.commit(Change{Type: ServiceAdded, Key: key, Service: &svc})
+	case before != svc:
+		s.commit(Change{Type: ServiceUpdated, Key: key, Service: &svc})
+	}
+}
+
+// RemoveService deletes a service if present
+func (s *Store) RemoveService(namespace, name string) {
+	key := ServiceKey(namespace, name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if before, ok := s.g.Services[key]; ok {
+		delete(s.g.Services, key)
+		s.commit(Change{Type: ServiceRemoved, Key: key, Service: &before})
+	}
+}
+
+// ReplaceEdges swaps the whole edge set, as produced by a metrics poll
+func (s *Store) ReplaceEdges(edges []Edge) {
+	next := append([]Edge{}, edges...)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changes := Diff(&MeshGraph{Edges: s.g.Edges}, &MeshGraph{Edges: next})
+	s.g.Edges = next
+	s.commit(changes...)
+}
+
+// ReplaceWorkloads swaps the workload nodes of one namespace
+func (s *Store) ReplaceWorkloads(namespace string, workloads map[string]Workload) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before := make(map[string]Workload)
+	for key, w := range s.g.Workloads {
+		if w.Namespace == namespace {
+			before[key] = w
+			delete(s.g.Workloads, key)
+		}
+	}
+	after := make(map[string]Workload, len(workloads))
+	for key, w := range workloads {
+		w.Services = append([]string(nil), w.Services...)
+		after[key] = w
+		s.g.Workloads[key] = w
+	}
+	s.commit(Diff(&MeshGraph{Workloads: before}, &MeshGraph{Workloads: after})...)
+}
+
+// PutWorkload adds or replaces one workload node
+func (s *Store) PutWorkload(w Workload) {
+	w.Services = append([]string(nil), w.Services...)
+	key := WorkloadKey(w.Namespace, w.Kind, w.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before := make(map[string]Workload)
+	if old, ok := s.g.Workloads[key]; ok {
+		before[key] = old
+	}
+	s.g.Workloads[key] = w
+	s.commit(Diff(&MeshGraph{Workloads: before}, &MeshGraph{Workloads: map[string]Workload{key: w}})...)
+}
+
+// PutPolicy adds or replaces an authorization policy
+func (s *Store) PutPolicy(policy AuthPolicy) {
+	policy.Spec = copyMap(policy.Spec)
+	key := PolicyKey(policy.Namespace, policy.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before, ok := s.g.AuthPolicies[key]
+	s.g.AuthPolicies[key] = policy
+	if !ok || !reflect.DeepEqual(before, policy) {
+		s.commit(Change{Type: PolicyApplied, Key: key, Policy: &policy})
+	}
+}
+
+// RemovePolicy deletes an authorization policy if present
+func (s *Store) RemovePolicy(namespace, name string) {
+	key := PolicyKey(namespace, name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if before, ok := s.g.AuthPolicies[key]; ok {
+		delete(s.g.AuthPolicies, key)
+		s.commit(Change{Type: PolicyRemoved, Key: key, Policy: &before})
+	}
+}
+
+// PutResource adds or replaces a policy or route resource
+func (s *Store) PutResource(res Resource) {
+	res.Spec = copyMap(res.Spec)
+	res.Annotations = maps.Clone(res.Annotations)
+	key := ResourceKey(res.Namespace, res.Kind, res.Name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	before, ok := s.g.Resources[key]
+	s.g.Resources[key] = res
+	if !ok || !reflect.DeepEqual(before, res) {
+		s.commit(Change{Type: ResourceApplied, Key: key, Resource: &res})
+	}
+}
+
+// SetSource records the freshness of a data source
+func (s *Store) SetSource(name string, status SourceStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if before, ok := s.g.Sources[name]; !ok || before != status {
+		s.g.Sources[name] = status
+		s.commit(Change{Type: SourceUpdated, Key: name, Source: &status})
+	}
+}
+
+// RemoveResource deletes a policy or route resource if present
+func (s *Store) RemoveResource(namespace, kind, name string) {
+	key := ResourceKey(namespace, kind, name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if before, ok := s.g.Resources[key]; ok {
+		delete(s.g.Resources, key)
+		s.commit(Change{Type: ResourceRemoved, Key: key, Resource: &before})
+	}
+}
@@ -0,0 +1,100 @@
+// internal/graph/store_test.go
+
+package graph
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestStore_MutationsNotifyObservers(t *testing.T) {
+	s := NewStore()
+	var got []ChangeType
+	s.Observe(func(changes []Change) {
+		for _, c := range changes {
+			got = append(got, c.Type)
+		}
+	})
+
+	s.UpsertService(Service{Name: "web", Namespace: "shop"})
+	s.UpsertService(Service{Name: "web", Namespace: "shop"}) // unchanged
+	s.UpsertService(Service{Name: "web", Namespace: "shop", Meshed: true})
+	s.ReplaceEdges([]Edge{{SrcNamespace: "shop", Src: "web", DstNamespace: "shop", Dst: "cart", RPS: 1}})
+	s.PutPolicy(AuthPolicy{Name: "allow", Namespace: "shop", Spec: map[string]interface{}{}})
+	s.ReplaceWorkloads("shop", map[string]Workload{
+		WorkloadKey("shop", "Deployment", "web"): {Kind: "Deployment", Name: "web", Namespace: "shop"},
+	})
+	s.PutWorkload(Workload{Kind: "Deployment", Name: "web", Namespace: "shop", MeshedPods: 1})
+	s.PutWorkload(Workload{Kind: "Deployment", Name: "web", Namespace: "shop", MeshedPods: 1}) // unchanged
+	s.ReplaceWorkloads("shop", nil)
+	s.RemoveService("shop", "web")
+	s.RemoveService("shop", "web") // already gone
+
+	want := []ChangeType{ServiceAdded, ServiceUpdated, EdgeAdded, PolicyApplied, WorkloadAdded, WorkloadUpdated, WorkloadRemoved, ServiceRemoved}
+	if len(got) != len(want) {
+		t.Fatalf("expected changes %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d: expected %d, got %d", i, want[i], got[i])
+		}
+	}
+}
+
+func TestStore_Sequence(t *testing.T) {
+	s := NewStore()
+	s.UpsertService(Service{Name: "web", Namespace: "shop"})
+	if s.Revision().Seq != 0 {
+		t.Errorf("expected an unsequenced store to keep its revision, got %+v", s.Revision())
+	}
+
+	s.Sequence("collector@host:1")
+	s.UpsertService(Service{Name: "web", Namespace: "shop", Meshed: true})
+	s.UpsertService(Service{Name: "web", Namespace: "shop", Meshed: true}) // unchanged
+	s.PutPolicy(AuthPolicy{Name: "allow", Namespace: "shop"})
+	if rev := s.Revision(); rev.Seq != 2 || rev.Origin != "collector@host:1" || rev.Timestamp.IsZero() {
+		t.Errorf("expected each change to advance the revision, got %+v", rev)
+	}
+}
+
+func TestStore_SnapshotIsACopy(t *testing.T) {
+	s := NewStore()
+	spec := map[string]interface{}{"targetRef": map[string]interface{}{"name": "web"}}
+	s.PutPolicy(AuthPolicy{Name: "allow", Namespace: "shop", Spec: spec})
+	spec["targetRef"] = "mutated by caller"
+
+	snap := s.Snapshot()
+	snap.Services["shop/web"] = Service{Name: "web", Namespace: "shop"}
+	snap.AuthPolicies["shop/allow"].Spec["extra"] = true
+
+	if len(s.Snapshot().Services) != 0 {
+		t.Errorf("snapshot writes must not reach the store")
+	}
+	policy := s.Policies()["shop/allow"]
+	if _, ok := policy.Spec["targetRef"].(map[string]interface{}); !ok || policy.Spec["extra"] != nil {
+		t.Errorf("expected stored spec to be isolated from callers, got %+v", policy.Spec)
+	}
+}
+
+func TestStore_ConcurrentAccess(t *testing.T) {
+	s := NewStore()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.UpsertService(Service{Name: "web", Namespace: "shop", MeshedPods: j})
+				s.ReplaceEdges([]Edge{{Src: "web", Dst: "cart", RPS: float64(j)}})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = s.Snapshot()
+				s.View(func(g *MeshGraph) { _ = g.FilterEdges(EdgeFilter{}) })
+			}
+		}()
+	}
+	wg.Wait()
+}
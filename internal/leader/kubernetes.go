@@ -0,0 +1,93 @@
+// internal/leader/kubernetes.go
+
+package leader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// kubernetesElector elects a leader through a coordination.k8s.io Lease
+type kubernetesElector struct {
+	elector   *leaderelection.LeaderElector
+	callbacks Callbacks
+
+	mu      sync.Mutex
+	leading bool
+}
+
+// NewKubernetes elects a leader through the Lease namespace/name, so HA
+// works without Redis. ttl is the lease duration; it is renewed well before.
+func NewKubernetes(client kubernetes.Interface, namespace, name, id string, ttl time.Duration, callbacks Callbacks) (Elector, error) {
+	k := &kubernetesElector{callbacks: callbacks}
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Client:     client.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: id},
+		},
+		LeaseDuration:   ttl,
+		RenewDeadline:   ttl * 2 / 3,
+		RetryPeriod:     ttl / 5,
+		ReleaseOnCancel: true,
+		Name:            name,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: k.started,
+			OnStoppedLeading: k.stopped,
+			OnNewLeader: func(identity string) {
+				fmt.Printf("Lease: %s/%s held by %s\n", namespace, name, identity)
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("configuring lease %s/%s: %w", namespace, name, err)
+	}
+	k.elector = elector
+	return k, nil
+}
+
+// Run campaigns again after every lost term until ctx is done
+func (k *kubernetesElector) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		k.elector.Run(ctx)
+	}
+}
+
+func (k *kubernetesElector) IsLeader() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.leading
+}
+
+// started runs in its own goroutine; by the time it gets the lock the term
+// may already be over, which stopped signals by cancelling ctx first
+func (k *kubernetesElector) started(ctx context.Context) {
+	k.mu.Lock()
+	if ctx.Err() != nil {
+		k.mu.Unlock()
+		return
+	}
+	k.leading = true
+	k.mu.Unlock()
+	if k.callbacks.OnStartedLeading != nil {
+		k.callbacks.OnStartedLeading(ctx)
+	}
+}
+
+// stopped is also called by client-go when Run returns without ever leading
+func (k *kubernetesElector) stopped() {
+	k.mu.Lock()
+	wasLeading := k.leading
+	k.leading = false
+	k.mu.Unlock()
+	if wasLeading && k.callbacks.OnStoppedLeading != nil {
+		k.callbacks.OnStoppedLeading()
+	}
+}
@@ -0,0 +1,53 @@
+// internal/leader/kubernetes_test.go
+
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKubernetesElector(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+
+	elector, err := NewKubernetes(client, "linkerd-mcp", "mcp-collector", "pod-a", time.Second, Callbacks{
+		OnStartedLeading: func(ctx context.Context) { close(started) },
+		OnStoppedLeading: func() { close(stopped) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx)
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected to acquire the lease")
+	}
+	if !elector.IsLeader() {
+		t.Errorf("expected IsLeader after acquiring")
+	}
+	lease, err := client.CoordinationV1().Leases("linkerd-mcp").Get(context.Background(), "mcp-collector", metav1.GetOptions{})
+	if err != nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != "pod-a" {
+		t.Fatalf("expected lease held by pod-a, got %+v (err %v)", lease, err)
+	}
+
+	cancel()
+	<-done
+	<-stopped
+	if elector.IsLeader() {
+		t.Errorf("expected to step down on shutdown")
+	}
+}
@@ -0,0 +1,25 @@
+// internal/leader/leader.go
+
+package leader
+
+import (
+	"context"
+)
+
+// Elector decides which replica leads. Only the leader should publish the
+// graph and reconcile cluster state.
+type Elector interface {
+	// Run takes part in the election until ctx is done, then steps down
+	Run(ctx context.Context)
+	// IsLeader reports whether this replica currently leads
+	IsLeader() bool
+}
+
+// Callbacks are invoked as leadership changes hands
+type Callbacks struct {
+	// OnStartedLeading runs in its own goroutine when leadership is gained;
+	// ctx is cancelled as soon as it is lost
+	OnStartedLeading func(ctx context.Context)
+	// OnStoppedLeading runs when leadership is lost or given up
+	OnStoppedLeading func()
+}
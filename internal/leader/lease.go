@@ -0,0 +1,168 @@
+// internal/leader/lease.go
+
+package leader
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Lock is a shared key with an expiry that at most one holder owns at a time
+type Lock interface {
+	// TryAcquireLeader takes the lock when it is free, or refreshes it when
+	// holder already owns it
+	TryAcquireLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// RenewLeader extends the lock only while holder still owns it
+	RenewLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// ReleaseLeader frees the lock only if holder still owns it
+	ReleaseLeader(ctx context.Context, holder string) error
+}
+
+// lease elects a leader by polling a Lock
+type lease struct {
+	lock   Lock
+	name   string
+	holder string
+	ttl    time.Duration
+	// renewDeadline is how long the leader keeps leading without a
+	// successful renewal; it is below ttl so the leader steps down before
+	// another holder can acquire the expired lock
+	renewDeadline time.Duration
+	callbacks     Callbacks
+
+	mu        sync.Mutex
+	leading   bool
+	renewedAt time.Time
+	cancel    context.CancelFunc
+}
+
+// NewLease elects a leader through lock, which name identifies in logs. id
+// is normally the pod UID. The lease expires ttl after the last renewal; it
+// is renewed every ttl/3, and the leader steps down once 2/3 of ttl pass
+// without a successful renewal.
+func NewLease(lock Lock, name, id string, ttl time.Duration, callbacks Callbacks) Elector {
+	return &lease{lock: lock, name: name, holder: id, ttl: ttl, renewDeadline: ttl * 2 / 3, callbacks: callbacks}
+}
+
+// IsLeader reports whether the lease is currently held
+func (l *lease) IsLeader() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.leading
+}
+
+// Run acquires and renews the lease until ctx is done, then releases it
+func (l *lease) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			l.release()
+			return
+		case <-timer.C:
+		}
+		l.tick(ctx)
+		// Wake up no later than the renew deadline, to step down on time
+		wait := l.ttl / 3
+		if deadline, leading := l.deadline(); leading && time.Until(deadline) < wait {
+			wait = max(time.Until(deadline), 0)
+		}
+		timer.Reset(wait)
+	}
+}
+
+// deadline returns when the leader must step down unless renewed, and
+// whether the lease is held
+func (l *lease) deadline() (time.Time, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.renewedAt.Add(l.renewDeadline), l.leading
+}
+
+func (l *lease) tick(ctx context.Context) {
+	if deadline, leading := l.deadline(); leading {
+		if !time.Now().Before(deadline) {
+			fmt.Printf("Lease: renew deadline of %s passed\n", l.name)
+			l.stopLeading()
+			return
+		}
+		// A renewal that hangs must not keep us leading past the deadline
+		renewCtx, cancel := context.WithDeadline(ctx, deadline)
+		ok, err := l.lock.RenewLeader(renewCtx, l.holder, l.ttl)
+		cancel()
+		switch {
+		case err != nil && ctx.Err() == nil:
+			// The backend may only be unreachable; we still lead until the renew deadline
+			fmt.Printf("Lease: failed to renew %s: %v\n", l.name, err)
+			if !time.Now().Before(deadline) {
+				l.stopLeading()
+			}
+		case err == nil && !ok:
+			fmt.Printf("Lease: %s taken over by another holder\n", l.name)
+			l.stopLeading()
+		case err == nil:
+			l.mu.Lock()
+			l.renewedAt = time.Now()
+			l.mu.Unlock()
+		}
+		return
+	}
+
+	ok, err := l.lock.TryAcquireLeader(ctx, l.holder, l.ttl)
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Printf("Lease: failed to acquire %s: %v\n", l.name, err)
+		}
+		return
+	}
+	if ok {
+		l.startLeading(ctx)
+	}
+}
+
+func (l *lease) startLeading(ctx context.Context) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	l.mu.Lock()
+	l.leading = true
+	l.renewedAt = time.Now()
+	l.cancel = cancel
+	l.mu.Unlock()
+
+	fmt.Printf("Lease: %s acquired by %s\n", l.name, l.holder)
+	if l.callbacks.OnStartedLeading != nil {
+		go l.callbacks.OnStartedLeading(leaderCtx)
+	}
+}
+
+func (l *lease) stopLeading() {
+	l.mu.Lock()
+	if !l.leading {
+		l.mu.Unlock()
+		return
+	}
+	l.leading = false
+	l.cancel()
+	l.mu.Unlock()
+
+	fmt.Printf("Lease: %s lost by %s\n", l.name, l.holder)
+	if l.callbacks.OnStoppedLeading != nil {
+		l.callbacks.OnStoppedLeading()
+	}
+}
+
+// release gives the lease up so a standby can take over without waiting for
+// it to expire
+func (l *lease) release() {
+	if !l.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := l.lock.ReleaseLeader(ctx, l.holder); err != nil {
+		fmt.Printf("Lease: failed to release %s: %v\n", l.name, err)
+	}
+	l.stopLeading()
+}
@@ -0,0 +1,142 @@
+// internal/leader/lease_test.go
+
+package leader
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLock is a Lock whose holder the test can overwrite
+type fakeLock struct {
+	mu     sync.Mutex
+	holder string
+	// hang makes renewals block until their context is done
+	hang bool
+}
+
+func (f *fakeLock) TryAcquireLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holder == "" || f.holder == holder {
+		f.holder = holder
+		return true, nil
+	}
+	return false, nil
+}
+
+func (f *fakeLock) RenewLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	hang := f.hang
+	f.mu.Unlock()
+	if hang {
+		<-ctx.Done()
+		return false, ctx.Err()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.holder == holder, nil
+}
+
+func (f *fakeLock) setHang(hang bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hang = hang
+}
+
+func (f *fakeLock) ReleaseLeader(ctx context.Context, holder string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holder == holder {
+		f.holder = ""
+	}
+	return nil
+}
+
+func (f *fakeLock) set(holder string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.holder = holder
+}
+
+func (f *fakeLock) get() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.holder
+}
+
+func TestLease_Failover(t *testing.T) {
+	lock := &fakeLock{}
+	ctx := context.Background()
+
+	started := make(chan context.Context, 1)
+	stopped := make(chan struct{}, 1)
+	a := NewLease(lock, "mesh:leader", "pod-a", 300*time.Millisecond, Callbacks{
+		OnStartedLeading: func(ctx context.Context) { started <- ctx },
+		OnStoppedLeading: func() { stopped <- struct{}{} },
+	}).(*lease)
+	b := NewLease(lock, "mesh:leader", "pod-b", 300*time.Millisecond, Callbacks{}).(*lease)
+
+	a.tick(ctx)
+	b.tick(ctx)
+	if !a.IsLeader() || b.IsLeader() {
+		t.Fatalf("expected only pod-a to lead")
+	}
+	leaderCtx := <-started
+
+	// Someone else takes the lock: the renew fails and pod-a steps down
+	lock.set("pod-b")
+	a.tick(ctx)
+	<-stopped
+	if a.IsLeader() || leaderCtx.Err() == nil {
+		t.Errorf("expected pod-a to lose leadership and its context to be cancelled")
+	}
+
+	// Releasing only frees the lock for its holder
+	b.tick(ctx)
+	if !b.IsLeader() {
+		t.Fatalf("expected pod-b to lead")
+	}
+	a.release()
+	if got := lock.get(); got != "pod-b" {
+		t.Errorf("pod-a must not release pod-b's lease, holder=%q", got)
+	}
+	b.release()
+	if lock.get() != "" || b.IsLeader() {
+		t.Errorf("expected pod-b to release the lease")
+	}
+}
+
+func TestLease_RenewDeadline(t *testing.T) {
+	lock := &fakeLock{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stopped := make(chan time.Time, 1)
+	ttl := 300 * time.Millisecond
+	a := NewLease(lock, "mesh:leader", "pod-a", ttl, Callbacks{
+		OnStoppedLeading: func() { stopped <- time.Now() },
+	}).(*lease)
+	go a.Run(ctx)
+	for !a.IsLeader() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Renewals hang: pod-a steps down at the renew deadline, before the
+	// lock it can no longer renew expires for the others
+	lock.setHang(true)
+	start := time.Now()
+	select {
+	case at := <-stopped:
+		if elapsed := at.Sub(start); elapsed >= ttl {
+			t.Errorf("expected pod-a to step down before the lease expires, took %v", elapsed)
+		}
+	case <-time.After(2 * ttl):
+		t.Fatal("expected pod-a to step down")
+	}
+	if a.IsLeader() {
+		t.Errorf("expected pod-a not to lead")
+	}
+}
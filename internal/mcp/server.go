@@ -0,0 +1,228 @@
+// internal/mcp/server.go
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+const serverName = "linkerd2-mcp"
+
+// Server implements the Model Context Protocol on top of the MeshContext
+// gRPC service, so agents can reach the same handlers without a gRPC client.
+type Server struct {
+	backend   pb.MeshContextServer
+	version   string
+	tools     []toolHandler
+	resources []resourceHandler
+	// allowedOrigins may reach the HTTP transport besides localhost
+	allowedOrigins []string
+}
+
+type toolHandler struct {
+	tool
+	call func(ctx context.Context, args json.RawMessage) (*toolResult, error)
+}
+
+type resourceHandler struct {
+	resource
+	read func(g *graph.MeshGraph) interface{}
+}
+
+// NewServer returns an MCP server backed by the given MeshContext implementation
+func NewServer(backend pb.MeshContextServer, version string) *Server {
+	s := &Server{backend: backend, version: version}
+	s.registerTools()
+	s.registerResources()
+	return s
+}
+
+// HandleMessage processes one JSON-RPC message (or batch) and returns the
+// encoded reply, or nil when nothing must be sent back (notifications).
+func (s *Server) HandleMessage(ctx context.Context, msg []byte) []byte {
+	var raw json.RawMessage
+	if err := json.Unmarshal(msg, &raw); err != nil {
+		return encode(errorResponse(nil, codeParseError, "parse error"))
+	}
+	if len(raw) > 0 && raw[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(raw, &batch); err != nil || len(batch) == 0 {
+			return encode(errorResponse(nil, codeInvalidRequest, "invalid batch"))
+		}
+		var replies []*response
+		for _, m := range batch {
+			if resp := s.handleOne(ctx, m); resp != nil {
+				replies = append(replies, resp)
+			}
+		}
+		if len(replies) == 0 {
+			return nil
+		}
+		return encode(replies)
+	}
+	resp := s.handleOne(ctx, raw)
+	if resp == nil {
+		return nil
+	}
+	return encode(resp)
+}
+
+func (s *Server) handleOne(ctx context.Context, msg json.RawMessage) *response {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil || req.JSONRPC != "2.0" {
+		return errorResponse(req.ID, codeInvalidRequest, "invalid request")
+	}
+	if req.Method == "" {
+		// Client responses; the server never issues requests, so drop them
+		return nil
+	}
+	result, err := s.dispatch(ctx, &req)
+	if req.isNotification() {
+		return nil
+	}
+	if err != nil {
+		if rerr, ok := err.(*rpcError); ok {
+			return errorResponse(req.ID, rerr.Code, rerr.Message)
+		}
+		return errorResponse(req.ID, codeInternalError, err.Error())
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (s *Server) dispatch(ctx context.Context, req *request) (interface{}, error) {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+			}
+		}
+		return s.initialize(params), nil
+	case "ping":
+		return map[string]interface{}{}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "tools/list":
+		tools := make([]tool, 0, len(s.tools))
+		for _, t := range s.tools {
+			tools = append(tools, t.tool)
+		}
+		return map[string]interface{}{"tools": tools}, nil
+	case "tools/call":
+		var params toolCallParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.callTool(ctx, params)
+	case "resources/list":
+		resources := make([]resource, 0, len(s.resources))
+		for _, r := range s.resources {
+			resources = append(resources, r.resource)
+		}
+		return map[string]interface{}{"resources": resources}, nil
+	case "resources/templates/list":
+		return map[string]interface{}{"resourceTemplates": []interface{}{}}, nil
+	case "resources/read":
+		var params resourceReadParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.readResource(ctx, params.URI)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+func (s *Server) initialize(params initializeParams) map[string]interface{} {
+	version := supportedProtocolVersions[0]
+	for _, v := range supportedProtocolVersions {
+		if v == params.ProtocolVersion {
+			version = v
+			break
+		}
+	}
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities": map[string]interface{}{
+			"tools":     map[string]interface{}{"listChanged": false},
+			"resources": map[string]interface{}{"listChanged": false, "subscribe": false},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    serverName,
+			"version": s.version,
+		},
+		"instructions": "Query the Linkerd service mesh graph (services, call edges, authorization policies) and apply authorization policies.",
+	}
+}
+
+func (s *Server) callTool(ctx context.Context, params toolCallParams) (interface{}, error) {
+	for _, t := range s.tools {
+		if t.Name == params.Name {
+			result, err := t.call(ctx, params.Arguments)
+			if err != nil {
+				// Tool failures are reported in-band so the model can see them
+				return errorResult(err.Error()), nil
+			}
+			return result, nil
+		}
+	}
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", params.Name)}
+}
+
+func (s *Server) readResource(ctx context.Context, uri string) (interface{}, error) {
+	for _, r := range s.resources {
+		if r.URI != uri {
+			continue
+		}
+		g, err := s.meshGraph(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(r.read(g), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal resource %s: %w", uri, err)
+		}
+		return map[string]interface{}{
+			"contents": []resourceContents{{URI: uri, MimeType: r.MimeType, Text: string(data)}},
+		}, nil
+	}
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown resource: %s", uri)}
+}
+
+// meshGraph fetches the current graph through the gRPC handler
+func (s *Server) meshGraph(ctx context.Context) (*graph.MeshGraph, error) {
+	resp, err := s.backend.GetMeshGraph(ctx, &pb.GetMeshGraphRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return graph.FromProto(resp.GetGraph()), nil
+}
+
+func encode(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(errorResponse(nil, codeInternalError, err.Error()))
+	}
+	return data
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
+
+func textResult(text string) *toolResult {
+	return &toolResult{Content: []content{{Type: "text", Text: text}}}
+}
+
+func errorResult(text string) *toolResult {
+	return &toolResult{Content: []content{{Type: "text", Text: text}}, IsError: true}
+}
@@ -0,0 +1,205 @@
+// internal/mcp/server_test.go
+
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+)
+
+type fakeBackend struct {
+	pb.UnimplementedMeshContextServer
+	mesh    graph.MeshGraph
+	applied *pb.ApplyAuthorizationPolicyRequest
+	route   *pb.ApplyRouteRequest
+}
+
+func (f *fakeBackend) GetMeshGraph(ctx context.Context, req *pb.GetMeshGraphRequest) (*pb.GetMeshGraphResponse, error) {
+	g, err := graph.ToProto(&f.mesh)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetMeshGraphResponse{Graph: g}, nil
+}
+
+func (f *fakeBackend) GetCallGraph(ctx context.Context, req *pb.GetCallGraphRequest) (*pb.GetCallGraphResponse, error) {
+	resp := &pb.GetCallGraphResponse{}
+	for _, e := range f.mesh.FilterEdges(graph.EdgeFilter{Namespace: req.GetNamespace(), Source: req.GetSource(), NonTLSOnly: req.GetNonTlsOnly()}) {
+		resp.Edges = append(resp.Edges, graph.EdgeToProto(e))
+	}
+	return resp, nil
+}
+
+func (f *fakeBackend) ApplyAuthorizationPolicy(ctx context.Context, req *pb.ApplyAuthorizationPolicyRequest) (*pb.ApplyAuthorizationPolicyResponse, error) {
+	f.applied = req
+	return &pb.ApplyAuthorizationPolicyResponse{Accepted: true, Message: "Policy applied and published"}, nil
+}
+
+func (f *fakeBackend) DeleteAuthorizationPolicy(ctx context.Context, req *pb.DeleteAuthorizationPolicyRequest) (*pb.DeleteAuthorizationPolicyResponse, error) {
+	return &pb.DeleteAuthorizationPolicyResponse{Deleted: true, Message: "Policy deleted"}, nil
+}
+
+func (f *fakeBackend) ApplyGRPCRoute(ctx context.Context, req *pb.ApplyRouteRequest) (*pb.ApplyRouteResponse, error) {
+	f.route = req
+	return &pb.ApplyRouteResponse{Accepted: true, Message: "GRPCRoute applied"}, nil
+}
+
+func newTestServer() (*Server, *fakeBackend) {
+	backend := &fakeBackend{mesh: graph.MeshGraph{
+		Services: map[string]graph.Service{
+			"default/service-a": {Name: "service-a", Namespace: "default", Meshed: true},
+		},
+		Edges:        []graph.Edge{{Src: "service-a", Dst: "service-b", RPS: 1.5}},
+		AuthPolicies: map[string]graph.AuthPolicy{},
+	}}
+	return NewServer(backend, "test"), backend
+}
+
+func call(t *testing.T, s *Server, msg string) map[string]interface{} {
+	t.Helper()
+	reply := s.HandleMessage(context.Background(), []byte(msg))
+	if reply == nil {
+		t.Fatalf("expected a reply to %s", msg)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(reply, &out); err != nil {
+		t.Fatalf("invalid reply %s: %v", reply, err)
+	}
+	return out
+}
+
+func TestServer_Initialize(t *testing.T) {
+	s, _ := newTestServer()
+	out := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`)
+	result := out["result"].(map[string]interface{})
+	if result["protocolVersion"] != "2025-03-26" {
+		t.Errorf("expected negotiated version 2025-03-26, got %v", result["protocolVersion"])
+	}
+	if s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)) != nil {
+		t.Errorf("expected no reply to a notification")
+	}
+}
+
+func TestServer_ResponseMembers(t *testing.T) {
+	s, _ := newTestServer()
+	// A request answered with a null result still carries the member
+	out := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"notifications/initialized"}`)
+	if _, ok := out["result"]; !ok {
+		t.Errorf("expected a result member, got %v", out)
+	}
+	out = call(t, s, `{"jsonrpc":"2.0","id":2,"method":"nope"}`)
+	if _, ok := out["result"]; ok || out["error"] == nil {
+		t.Errorf("expected only an error member, got %v", out)
+	}
+}
+
+func TestServer_ToolsCall(t *testing.T) {
+	s, backend := newTestServer()
+
+	out := call(t, s, `{"jsonrpc":"2.0","id":"a","method":"tools/list"}`)
+	tools := out["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 7 {
+		t.Fatalf("expected 7 tools, got %d", len(tools))
+	}
+
+	out = call(t, s, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"apply_authorization_policy","arguments":{"namespace":"default","name":"allow-a","spec":{"targetRef":{"kind":"Server","name":"b"}},"adopt":true}}}`)
+	result := out["result"].(map[string]interface{})
+	if result["isError"] != false {
+		t.Fatalf("expected successful tool call, got %v", result)
+	}
+	if backend.applied == nil || backend.applied.Name != "allow-a" || !strings.Contains(backend.applied.JsonSpec, "targetRef") || !backend.applied.Adopt {
+		t.Errorf("expected policy to reach the backend, got %+v", backend.applied)
+	}
+
+	out = call(t, s, `{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"apply_authorization_policy","arguments":{"spec":{}}}}`)
+	if out["result"].(map[string]interface{})["isError"] != true {
+		t.Errorf("expected missing namespace to be reported as a tool error")
+	}
+
+	out = call(t, s, `{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"apply_grpc_route","arguments":{"namespace":"shop","name":"cart","spec":{"parentRefs":[{"kind":"Service","name":"cart"}]},"annotations":{"retry.linkerd.io/grpc":"unavailable"}}}}`)
+	if out["result"].(map[string]interface{})["isError"] != false {
+		t.Fatalf("expected successful tool call, got %v", out["result"])
+	}
+	if backend.route == nil || backend.route.Name != "cart" || backend.route.Annotations["retry.linkerd.io/grpc"] != "unavailable" {
+		t.Errorf("expected the route to reach the backend, got %+v", backend.route)
+	}
+}
+
+func TestServer_ResourcesRead(t *testing.T) {
+	s, _ := newTestServer()
+	out := call(t, s, `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"mesh://edges"}}`)
+	contents := out["result"].(map[string]interface{})["contents"].([]interface{})
+	text := contents[0].(map[string]interface{})["text"].(string)
+	var edges []graph.Edge
+	if err := json.Unmarshal([]byte(text), &edges); err != nil {
+		t.Fatalf("invalid edges resource: %v", err)
+	}
+	if len(edges) != 1 || edges[0].Dst != "service-b" {
+		t.Errorf("unexpected edges: %+v", edges)
+	}
+
+	out = call(t, s, `{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"mesh://nope"}}`)
+	if out["error"] == nil {
+		t.Errorf("expected error for unknown resource")
+	}
+}
+
+func TestServer_HTTPTransport(t *testing.T) {
+	s, _ := newTestServer()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	// Sessions start with initialize
+	resp, err := http.Post(ts.URL, "application/json", bytes.NewBufferString(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Mcp-Session-Id") == "" {
+		t.Errorf("expected 200 with session id, got %d", resp.StatusCode)
+	}
+	resp, err = http.Post(ts.URL, "application/json", bytes.NewBufferString(`{"jsonrpc":"2.0","id":2,"method":"ping"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Mcp-Session-Id") != "" {
+		t.Errorf("expected 200 without a new session id, got %d", resp.StatusCode)
+	}
+
+	// Browser pages must come from localhost or an allowed origin
+	s.AllowOrigins([]string{"https://agent.example.com"})
+	for origin, want := range map[string]int{
+		"http://localhost:6274":     http.StatusOK,
+		"https://agent.example.com": http.StatusOK,
+		"https://evil.example.com":  http.StatusForbidden,
+	} {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString(`{"jsonrpc":"2.0","id":3,"method":"ping"}`))
+		req.Header.Set("Origin", origin)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("origin %s: expected %d, got %d", origin, want, resp.StatusCode)
+		}
+	}
+
+	resp, err = http.Post(ts.URL, "application/json", bytes.NewBufferString(`{"jsonrpc":"2.0","method":"notifications/initialized"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected 202 for notification, got %d", resp.StatusCode)
+	}
+}
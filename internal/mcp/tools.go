@@ -0,0 +1,311 @@
+// internal/mcp/tools.go
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/eli-nomasec/linkerd2-mcp/internal/gen/pb"
+	"github.com/eli-nomasec/linkerd2-mcp/internal/graph"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func (s *Server) registerTools() {
+	s.tools = []toolHandler{
+		{
+			tool: tool{
+				Name:        "get_mesh_graph",
+				Description: "Return the full mesh graph: services, call edges with traffic metrics, and authorization policies.",
+				InputSchema: objectSchema(nil, nil),
+			},
+			call: s.getMeshGraph,
+		},
+		{
+			tool: tool{
+				Name:        "get_call_graph",
+				Description: "List service-to-service call edges with RPS, success rate, p50/p95/p99 latency and mTLS status. Filter by namespace, by source workload to see its dependencies, or to calls that are not fully mTLS'd.",
+				InputSchema: objectSchema(map[string]interface{}{
+					"namespace":    map[string]interface{}{"type": "string", "description": "Only edges whose source or destination is in this namespace"},
+					"source":       map[string]interface{}{"type": "string", "description": "Only edges whose source workload has this name"},
+					"non_tls_only": map[string]interface{}{"type": "boolean", "description": "Only edges where some traffic is not mTLS'd"},
+				}, nil),
+			},
+			call: s.getCallGraph,
+		},
+		{
+			tool: tool{
+				Name:        "apply_authorization_policy",
+				Description: "Create or update a Linkerd AuthorizationPolicy (policy.linkerd.io) in the given namespace. Reports the stored resourceVersion and UID, or the invalid fields and field-manager conflicts that prevented the apply.",
+				InputSchema: objectSchema(map[string]interface{}{
+					"namespace": map[string]interface{}{"type": "string", "description": "Namespace of the policy"},
+					"name":      map[string]interface{}{"type": "string", "description": "Name of the policy"},
+					"spec":      map[string]interface{}{"type": "object", "description": "AuthorizationPolicy spec (targetRef, requiredAuthenticationRefs)"},
+					"force":     map[string]interface{}{"type": "boolean", "description": "Take over fields owned by other field managers (e.g. kubectl) instead of failing on conflicts"},
+					"adopt":     map[string]interface{}{"type": "boolean", "description": "Take over an existing policy not created through this server, which is otherwise refused"},
+				}, []string{"namespace", "name", "spec"}),
+			},
+			call: s.applyAuthorizationPolicy,
+		},
+		{
+			tool: tool{
+				Name:        "delete_authorization_policy",
+				Description: "Delete a Linkerd AuthorizationPolicy created through this server. Policies not labelled as managed by linkerd2-mcp are never deleted.",
+				InputSchema: objectSchema(map[string]interface{}{
+					"namespace": map[string]interface{}{"type": "string", "description": "Namespace of the policy"},
+					"name":      map[string]interface{}{"type": "string", "description": "Name of the policy"},
+				}, []string{"namespace", "name"}),
+			},
+			call: s.deleteAuthorizationPolicy,
+		},
+		{
+			tool: tool{
+				Name:        "apply_http_route",
+				Description: "Create or update a Gateway API HTTPRoute attached to a Linkerd Server or a Service: header/path/method matches, traffic splitting by backendRefs weights, timeouts, and Linkerd retries via annotations.",
+				InputSchema: routeSchema("HTTPRoute", "retry.linkerd.io/http: 5xx,429"),
+			},
+			call: s.applyRoute("HTTPRoute", s.backend.ApplyHTTPRoute),
+		},
+		{
+			tool: tool{
+				Name:        "apply_grpc_route",
+				Description: "Create or update a Gateway API GRPCRoute attached to a Linkerd Server or a Service: service/method and header matches, traffic splitting by backendRefs weights, and Linkerd retries and timeouts via annotations.",
+				InputSchema: routeSchema("GRPCRoute", "retry.linkerd.io/grpc: unavailable"),
+			},
+			call: s.applyRoute("GRPCRoute", s.backend.ApplyGRPCRoute),
+		},
+		{
+			tool: tool{
+				Name:        "get_status",
+				Description: "Report how current the mesh graph is: per data source (Prometheus, Kubernetes informers) whether it is synced, when it last succeeded, and which graph fields are stale.",
+				InputSchema: objectSchema(nil, nil),
+			},
+			call: s.getStatus,
+		},
+	}
+}
+
+func (s *Server) registerResources() {
+	s.resources = []resourceHandler{
+		{
+			resource: resource{URI: "mesh://graph", Name: "graph", Description: "Full mesh graph", MimeType: "application/json"},
+			read:     func(g *graph.MeshGraph) interface{} { return g },
+		},
+		{
+			resource: resource{URI: "mesh://services", Name: "services", Description: "Services known to the mesh and their meshed status", MimeType: "application/json"},
+			read:     func(g *graph.MeshGraph) interface{} { return g.Services },
+		},
+		{
+			resource: resource{URI: "mesh://workloads", Name: "workloads", Description: "Deployments, StatefulSets and DaemonSets with replica, readiness and proxy details", MimeType: "application/json"},
+			read:     func(g *graph.MeshGraph) interface{} { return g.Workloads },
+		},
+		{
+			resource: resource{URI: "mesh://edges", Name: "edges", Description: "Observed service-to-service call edges", MimeType: "application/json"},
+			read:     func(g *graph.MeshGraph) interface{} { return g.Edges },
+		},
+		{
+			resource: resource{URI: "mesh://policies", Name: "policies", Description: "Authorization policies in the mesh graph", MimeType: "application/json"},
+			read:     func(g *graph.MeshGraph) interface{} { return g.AuthPolicies },
+		},
+		{
+			resource: resource{URI: "mesh://resources", Name: "resources", Description: "Linkerd Server, ServerAuthorization, MeshTLSAuthentication, NetworkAuthentication and Gateway API HTTPRoute/GRPCRoute resources", MimeType: "application/json"},
+			read:     func(g *graph.MeshGraph) interface{} { return g.Resources },
+		},
+	}
+}
+
+func (s *Server) getMeshGraph(ctx context.Context, _ json.RawMessage) (*toolResult, error) {
+	g, err := s.meshGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mesh graph: %w", err)
+	}
+	return textResult(string(data)), nil
+}
+
+func (s *Server) getCallGraph(ctx context.Context, args json.RawMessage) (*toolResult, error) {
+	var in struct {
+		Namespace  string `json:"namespace"`
+		Source     string `json:"source"`
+		NonTLSOnly bool   `json:"non_tls_only"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &in); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+	resp, err := s.backend.GetCallGraph(ctx, &pb.GetCallGraphRequest{
+		Namespace:  in.Namespace,
+		Source:     in.Source,
+		NonTlsOnly: in.NonTLSOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+	edges := graph.FromProto(&pb.MeshGraph{Edges: resp.GetEdges()}).Edges
+	data, err := json.MarshalIndent(edges, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal edges: %w", err)
+	}
+	return textResult(string(data)), nil
+}
+
+func (s *Server) applyAuthorizationPolicy(ctx context.Context, args json.RawMessage) (*toolResult, error) {
+	var in struct {
+		Namespace string                 `json:"namespace"`
+		Name      string                 `json:"name"`
+		Spec      map[string]interface{} `json:"spec"`
+		Force     bool                   `json:"force"`
+		Adopt     bool                   `json:"adopt"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if in.Namespace == "" || in.Name == "" {
+		return nil, fmt.Errorf("namespace and name are required")
+	}
+	spec, err := json.Marshal(in.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	resp, err := s.backend.ApplyAuthorizationPolicy(ctx, &pb.ApplyAuthorizationPolicyRequest{
+		Namespace: in.Namespace,
+		Name:      in.Name,
+		JsonSpec:  string(spec),
+		Force:     in.Force,
+		Adopt:     in.Adopt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applyResult(resp), nil
+}
+
+// applyResponse is the common shape of the Apply* responses
+type applyResponse interface {
+	GetAccepted() bool
+	GetMessage() string
+	GetErrors() []*pb.FieldError
+	GetConflicts() []*pb.FieldError
+	GetUid() string
+	GetResourceVersion() string
+	GetGeneration() int64
+}
+
+// applyResult reports the stored object, or why the apply was refused
+func applyResult(resp applyResponse) *toolResult {
+	if !resp.GetAccepted() {
+		msg := resp.GetMessage()
+		for _, fe := range append(resp.GetErrors(), resp.GetConflicts()...) {
+			msg += fmt.Sprintf("\n- %s (%s): %s", fe.GetField(), fe.GetType(), fe.GetMessage())
+		}
+		return errorResult(msg)
+	}
+	msg := resp.GetMessage()
+	if resp.GetUid() != "" {
+		msg += fmt.Sprintf("\nuid: %s\nresourceVersion: %s\ngeneration: %d", resp.GetUid(), resp.GetResourceVersion(), resp.GetGeneration())
+	}
+	return textResult(msg)
+}
+
+func (s *Server) deleteAuthorizationPolicy(ctx context.Context, args json.RawMessage) (*toolResult, error) {
+	var in struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if in.Namespace == "" || in.Name == "" {
+		return nil, fmt.Errorf("namespace and name are required")
+	}
+	resp, err := s.backend.DeleteAuthorizationPolicy(ctx, &pb.DeleteAuthorizationPolicyRequest{
+		Namespace: in.Namespace,
+		Name:      in.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.GetDeleted() {
+		return errorResult(resp.GetMessage()), nil
+	}
+	return textResult(resp.GetMessage()), nil
+}
+
+// routeSchema is the input schema of the apply tool for a route kind
+func routeSchema(kind, retryExample string) map[string]interface{} {
+	return objectSchema(map[string]interface{}{
+		"namespace":   map[string]interface{}{"type": "string", "description": "Namespace of the route"},
+		"name":        map[string]interface{}{"type": "string", "description": "Name of the route"},
+		"spec":        map[string]interface{}{"type": "object", "description": kind + " spec (parentRefs to a Server or Service, hostnames, rules)"},
+		"annotations": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}, "description": "Linkerd retry.linkerd.io/* and timeout.linkerd.io/* annotations, e.g. " + retryExample},
+		"force":       map[string]interface{}{"type": "boolean", "description": "Take over fields owned by other field managers instead of failing on conflicts"},
+		"adopt":       map[string]interface{}{"type": "boolean", "description": "Take over an existing route not created through this server, which is otherwise refused"},
+	}, []string{"namespace", "name", "spec"})
+}
+
+// applyRoute returns the tool call applying a route of kind through apply
+func (s *Server) applyRoute(kind string, apply func(context.Context, *pb.ApplyRouteRequest) (*pb.ApplyRouteResponse, error)) func(context.Context, json.RawMessage) (*toolResult, error) {
+	return func(ctx context.Context, args json.RawMessage) (*toolResult, error) {
+		var in struct {
+			Namespace   string                 `json:"namespace"`
+			Name        string                 `json:"name"`
+			Spec        map[string]interface{} `json:"spec"`
+			Annotations map[string]string      `json:"annotations"`
+			Force       bool                   `json:"force"`
+			Adopt       bool                   `json:"adopt"`
+		}
+		if err := json.Unmarshal(args, &in); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+		if in.Namespace == "" || in.Name == "" {
+			return nil, fmt.Errorf("namespace and name are required")
+		}
+		spec, err := json.Marshal(in.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec: %w", err)
+		}
+		resp, err := apply(ctx, &pb.ApplyRouteRequest{
+			Namespace:   in.Namespace,
+			Name:        in.Name,
+			JsonSpec:    string(spec),
+			Force:       in.Force,
+			Adopt:       in.Adopt,
+			Annotations: in.Annotations,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return applyResult(resp), nil
+	}
+}
+
+func (s *Server) getStatus(ctx context.Context, _ json.RawMessage) (*toolResult, error) {
+	resp, err := s.backend.GetStatus(ctx, &pb.GetStatusRequest{})
+	if err != nil {
+		return nil, err
+	}
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status: %w", err)
+	}
+	return textResult(string(data)), nil
+}
+
+func objectSchema(properties map[string]interface{}, required []string) map[string]interface{} {
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,118 @@
+// internal/mcp/transport.go
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// maxMessageSize bounds a single JSON-RPC message on either transport
+const maxMessageSize = 4 << 20
+
+// ServeStdio reads newline-delimited JSON-RPC messages from r and writes the
+// replies to w until r is closed or ctx is cancelled.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		reply := s.HandleMessage(ctx, line)
+		if reply == nil {
+			continue
+		}
+		if _, err := w.Write(append(reply, '\n')); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// AllowOrigins lets browser pages from the given origins (e.g.
+// "https://agent.example.com") reach the HTTP transport, besides localhost
+func (s *Server) AllowOrigins(origins []string) {
+	s.allowedOrigins = origins
+}
+
+// allowedOrigin reports whether a request with the given Origin header may
+// be served. Requests without one do not come from a browser page; pages
+// must be served from localhost or an allowed origin, which stops other
+// sites from driving the tools through DNS rebinding.
+func (s *Server) allowedOrigin(origin string) bool {
+	if origin == "" || slices.Contains(s.allowedOrigins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// ServeHTTP implements the streamable HTTP transport. Every POST carries one
+// message or batch and is answered with a single JSON body; the server never
+// initiates messages, so GET streams are not offered.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedOrigin(r.Header.Get("Origin")) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		// Sessions hold no server-side state, so termination is a no-op
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	reply := s.HandleMessage(r.Context(), body)
+	if reply == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if isInitialize(body) {
+		w.Header().Set("Mcp-Session-Id", newSessionID())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(reply)
+}
+
+// isInitialize reports whether msg is an initialize request, the one that
+// starts a session
+func isInitialize(msg []byte) bool {
+	var req request
+	return json.Unmarshal(msg, &req) == nil && req.Method == "initialize" && !req.isNotification()
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,126 @@
+// internal/policy/apply.go
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// ApplyResult is the outcome of a server-side apply the API server answered
+type ApplyResult struct {
+	// ResourceVersion, UID and Generation identify the object as stored
+	ResourceVersion string
+	UID             string
+	Generation      int64
+	// Conflicts lists fields owned by other field managers; nothing was
+	// applied. Retrying with force takes them over.
+	Conflicts []FieldError
+	// Errors lists why the API server rejected the object; nothing was applied
+	Errors []FieldError
+}
+
+// Apply stores obj with a server-side apply as FieldManager, with strict field
+// validation. Rejections and conflicts are reported in the result; failing
+// to get an answer from the API server returns an error.
+func Apply(ctx context.Context, dyn dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured, force bool) (*ApplyResult, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := dyn.Resource(gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager:    FieldManager,
+		Force:           &force,
+		FieldValidation: metav1.FieldValidationStrict,
+	})
+	switch {
+	case err == nil:
+		return &ApplyResult{
+			ResourceVersion: applied.GetResourceVersion(),
+			UID:             string(applied.GetUID()),
+			Generation:      applied.GetGeneration(),
+		}, nil
+	case apierrors.IsConflict(err):
+		return &ApplyResult{Conflicts: statusCauses(err)}, nil
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err):
+		return &ApplyResult{Errors: statusCauses(err)}, nil
+	default:
+		return nil, fmt.Errorf("applying %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+}
+
+// statusCauses lists the causes of an API server error, or its message when
+// it carries none
+func statusCauses(err error) []FieldError {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return []FieldError{{Type: metav1.CauseTypeFieldValueInvalid, Message: err.Error()}}
+	}
+	var causes []FieldError
+	if details := status.Status().Details; details != nil {
+		for _, cause := range details.Causes {
+			causes = append(causes, FieldError{Field: cause.Field, Type: cause.Type, Message: cause.Message})
+		}
+	}
+	if len(causes) == 0 {
+		causes = append(causes, FieldError{Type: metav1.CauseTypeFieldValueInvalid, Message: status.Status().Message})
+	}
+	return causes
+}
+
+// ErrNotManaged refuses to change an object this project did not create
+var ErrNotManaged = errors.New("not managed by " + FieldManager)
+
+// CheckManaged returns an error wrapping ErrNotManaged when the object
+// namespace/name exists but this project did not create it
+func CheckManaged(ctx context.Context, dyn dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string) error {
+	obj, err := dyn.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsManaged(obj) {
+		return fmt.Errorf("%s %s/%s is %w", obj.GetKind(), namespace, name, ErrNotManaged)
+	}
+	return nil
+}
+
+// Delete deletes the object namespace/name if it is managed, reporting
+// whether it existed. The delete is preconditioned on the UID and
+// resourceVersion that were checked, so an object replaced or relabelled in
+// the meantime is left alone.
+func Delete(ctx context.Context, dyn dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string) (bool, error) {
+	client := dyn.Resource(gvr).Namespace(namespace)
+	obj, err := client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !IsManaged(obj) {
+		return false, fmt.Errorf("%s %s/%s is %w", obj.GetKind(), namespace, name, ErrNotManaged)
+	}
+	uid, rv := obj.GetUID(), obj.GetResourceVersion()
+	err = client.Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &uid, ResourceVersion: &rv},
+	})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("deleting %s %s/%s: %w", obj.GetKind(), namespace, name, err)
+	}
+	return true, nil
+}
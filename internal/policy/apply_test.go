@@ -0,0 +1,122 @@
+// internal/policy/apply_test.go
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestApply(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	conflict := false
+	dyn.PrependReactor("patch", "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		a := action.(k8stesting.PatchActionImpl)
+		if a.GetPatchType() != types.ApplyPatchType {
+			t.Errorf("expected a server-side apply, got %s", a.GetPatchType())
+		}
+		if conflict {
+			return true, nil, apierrors.NewApplyConflict([]metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Field:   ".spec.targetRef.name",
+				Message: `conflict with "kubectl": .spec.targetRef.name`,
+			}}, "Apply failed with 1 conflict")
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetName(a.GetName())
+		obj.SetNamespace(a.GetNamespace())
+		obj.SetResourceVersion("42")
+		obj.SetUID(types.UID("3f1c"))
+		obj.SetGeneration(2)
+		return true, obj, nil
+	})
+
+	obj := AuthorizationPolicy("shop", "web", map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "web-http"}})
+	result, err := Apply(context.Background(), dyn, AuthorizationPolicyGVR, obj, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ResourceVersion != "42" || result.UID != "3f1c" || result.Generation != 2 || len(result.Conflicts) != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// Fields owned by another manager block the apply
+	conflict = true
+	result, err = Apply(context.Background(), dyn, AuthorizationPolicyGVR, obj, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].Field != ".spec.targetRef.name" || result.ResourceVersion != "" {
+		t.Errorf("expected a conflict on .spec.targetRef.name, got %+v", result)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	spec := map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "web-http"}}
+	managed := AuthorizationPolicy("shop", "web", spec)
+	handWritten := AuthorizationPolicy("shop", "admin", spec)
+	handWritten.SetLabels(nil)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), managed, handWritten)
+
+	deleted, err := Delete(context.Background(), dyn, AuthorizationPolicyGVR, "shop", "web")
+	if err != nil || !deleted {
+		t.Fatalf("expected the managed policy to be deleted, got %v, %v", deleted, err)
+	}
+	deleted, err = Delete(context.Background(), dyn, AuthorizationPolicyGVR, "shop", "web")
+	if err != nil || deleted {
+		t.Errorf("expected a missing policy to report false, got %v, %v", deleted, err)
+	}
+
+	// Policies without the managed-by label are never deleted
+	_, err = Delete(context.Background(), dyn, AuthorizationPolicyGVR, "shop", "admin")
+	if !errors.Is(err, ErrNotManaged) {
+		t.Errorf("expected ErrNotManaged, got %v", err)
+	}
+	if _, err := dyn.Resource(AuthorizationPolicyGVR).Namespace("shop").Get(context.Background(), "admin", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the hand-written policy to remain: %v", err)
+	}
+}
+
+func TestCheckManaged(t *testing.T) {
+	spec := map[string]interface{}{"targetRef": map[string]interface{}{"kind": "Server", "name": "web-http"}}
+	handWritten := AuthorizationPolicy("shop", "admin", spec)
+	handWritten.SetLabels(nil)
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), AuthorizationPolicy("shop", "web", spec), handWritten)
+
+	for name, want := range map[string]error{"web": nil, "new": nil, "admin": ErrNotManaged} {
+		if err := CheckManaged(context.Background(), dyn, AuthorizationPolicyGVR, "shop", name); !errors.Is(err, want) {
+			t.Errorf("shop/%s: expected %v, got %v", name, want, err)
+		}
+	}
+}
+
+func TestLastWrittenByUs(t *testing.T) {
+	obj := AuthorizationPolicy("shop", "web", nil)
+	if LastWrittenByUs(obj) {
+		t.Errorf("expected an object without managed fields not to count as ours")
+	}
+	at := func(sec int64) *metav1.Time { tm := metav1.Unix(sec, 0); return &tm }
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: at(100)},
+		{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply, Time: at(200)},
+		{Manager: "linkerd-policy-controller", Operation: metav1.ManagedFieldsOperationUpdate, Time: at(300), Subresource: "status"},
+	})
+	if !LastWrittenByUs(obj) {
+		t.Errorf("expected our apply after the edit to count, status writes aside")
+	}
+	fields := obj.GetManagedFields()
+	fields[0].Time = at(200)
+	obj.SetManagedFields(fields)
+	if LastWrittenByUs(obj) {
+		t.Errorf("expected an edit in the same second as our apply to win")
+	}
+}
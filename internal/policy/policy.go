@@ -0,0 +1,64 @@
+// internal/policy/policy.go
+
+package policy
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// FieldManager owns the fields this project writes through server-side apply
+const FieldManager = "linkerd2-mcp"
+
+// ManagedByLabel, set to FieldManager, marks the objects this project
+// created; they are the only ones it deletes
+const ManagedByLabel = "app.kubernetes.io/managed-by"
+
+// IsManaged reports whether obj was created by this project
+func IsManaged(obj metav1.Object) bool {
+	return obj.GetLabels()[ManagedByLabel] == FieldManager
+}
+
+// LastWrittenByUs reports whether the latest write to obj's main resource,
+// as recorded in its managed fields, was made by this project. Writes to
+// subresources such as status are ignored; on a tie another manager wins.
+func LastWrittenByUs(obj metav1.Object) bool {
+	fields := obj.GetManagedFields()
+	var last *metav1.ManagedFieldsEntry
+	for i, entry := range fields {
+		if entry.Subresource != "" || entry.Time == nil {
+			continue
+		}
+		if last == nil || entry.Time.After(last.Time.Time) || (entry.Time.Equal(last.Time) && entry.Manager != FieldManager) {
+			last = &fields[i]
+		}
+	}
+	return last != nil && last.Manager == FieldManager
+}
+
+// AuthorizationPolicyGVR is the Linkerd AuthorizationPolicy resource
+var AuthorizationPolicyGVR = schema.GroupVersionResource{Group: "policy.linkerd.io", Version: "v1alpha1", Resource: "authorizationpolicies"}
+
+// AuthorizationPolicy builds the managed AuthorizationPolicy object for spec
+func AuthorizationPolicy(namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return managedObject(AuthorizationPolicyGVR, "AuthorizationPolicy", namespace, name, spec)
+}
+
+// managedObject builds an object labelled as managed by this project
+func managedObject(gvr schema.GroupVersionResource, kind, namespace, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": gvr.GroupVersion().String(),
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels": map[string]interface{}{
+					ManagedByLabel: FieldManager,
+				},
+			},
+			"spec": spec,
+		},
+	}
+}
@@ -0,0 +1,200 @@
+// internal/policy/route.go
+
+package policy
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+// HTTPRouteGVR and GRPCRouteGVR are the Gateway API route resources
+var (
+	HTTPRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "httproutes"}
+	GRPCRouteGVR = schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: "v1", Resource: "grpcroutes"}
+)
+
+//go:embed schemas/httproute.json
+var httpRouteSchemaJSON []byte
+
+//go:embed schemas/grpcroute.json
+var grpcRouteSchemaJSON []byte
+
+// routeKind describes a route kind accepted by the API
+type routeKind struct {
+	gvr    schema.GroupVersionResource
+	schema *spec.Schema
+	// retryOn is the annotation listing the responses that are retried
+	retryOn string
+}
+
+var routeKinds = map[string]routeKind{
+	"HTTPRoute": {gvr: HTTPRouteGVR, schema: mustSchema(httpRouteSchemaJSON), retryOn: "retry.linkerd.io/http"},
+	"GRPCRoute": {gvr: GRPCRouteGVR, schema: mustSchema(grpcRouteSchemaJSON), retryOn: "retry.linkerd.io/grpc"},
+}
+
+// Linkerd's retry and timeout annotations, common to both route kinds
+const (
+	retryLimitAnnotation      = "retry.linkerd.io/limit"
+	retryTimeoutAnnotation    = "retry.linkerd.io/timeout"
+	requestTimeoutAnnotation  = "timeout.linkerd.io/request"
+	responseTimeoutAnnotation = "timeout.linkerd.io/response"
+	idleTimeoutAnnotation     = "timeout.linkerd.io/idle"
+)
+
+var (
+	httpStatusPattern = regexp.MustCompile(`^([1-5]xx|[1-5][0-9]{2}(-[1-5][0-9]{2})?)$`)
+	grpcCodes         = map[string]bool{
+		"cancelled": true, "unknown": true, "invalid-argument": true, "deadline-exceeded": true,
+		"not-found": true, "already-exists": true, "permission-denied": true, "resource-exhausted": true,
+		"failed-precondition": true, "aborted": true, "out-of-range": true, "unimplemented": true,
+		"internal": true, "unavailable": true, "data-loss": true, "unauthenticated": true,
+	}
+	// parentGroups lists the groups of the parents Linkerd attaches routes
+	// to: policy.linkerd.io Servers and core Services
+	parentGroups = map[string][]string{"Server": {"policy.linkerd.io"}, "Service": {"", "core"}}
+)
+
+// RouteGVR returns the resource of a route kind (HTTPRoute or GRPCRoute)
+func RouteGVR(kind string) (schema.GroupVersionResource, bool) {
+	k, ok := routeKinds[kind]
+	return k.gvr, ok
+}
+
+// Route builds the managed route object of kind for spec and its Linkerd
+// annotations
+func Route(kind, namespace, name string, spec map[string]interface{}, annotations map[string]string) *unstructured.Unstructured {
+	obj := managedObject(routeKinds[kind].gvr, kind, namespace, name, spec)
+	if len(annotations) > 0 {
+		obj.SetAnnotations(annotations)
+	}
+	return obj
+}
+
+// RouteAnnotations returns the Linkerd retry and timeout annotations among
+// annotations, or nil when there are none
+func RouteAnnotations(annotations map[string]string) map[string]string {
+	var out map[string]string
+	for key, value := range annotations {
+		if strings.HasPrefix(key, "retry.linkerd.io/") || strings.HasPrefix(key, "timeout.linkerd.io/") {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[key] = value
+		}
+	}
+	return out
+}
+
+// Route validates an HTTPRoute or GRPCRoute, like AuthorizationPolicy
+func (v *Validator) Route(ctx context.Context, kind, namespace, name string, spec map[string]interface{}, annotations map[string]string) ([]FieldError, error) {
+	if errs := ValidateRoute(kind, namespace, name, spec, annotations); len(errs) > 0 {
+		return errs, nil
+	}
+	if v.dyn == nil {
+		return nil, nil
+	}
+	return DryRun(ctx, v.dyn, routeKinds[kind].gvr, Route(kind, namespace, name, spec, annotations))
+}
+
+// ValidateRoute checks a route's name and namespace, its spec against the
+// Gateway API schema, that it attaches to Servers or Services, and its
+// Linkerd annotations
+func ValidateRoute(kind, namespace, name string, spec map[string]interface{}, annotations map[string]string) []FieldError {
+	k, ok := routeKinds[kind]
+	if !ok {
+		return []FieldError{{Field: "kind", Type: metav1.CauseTypeFieldValueNotSupported, Message: fmt.Sprintf("unsupported route kind %q", kind)}}
+	}
+	errs := validateMetadata(namespace, name)
+	var value interface{}
+	if spec != nil {
+		value = spec
+	}
+	errs = append(errs, validateSchema(k.schema, "spec", value)...)
+	errs = append(errs, validateParentRefs(spec)...)
+	errs = append(errs, validateRouteAnnotations(k, annotations)...)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+// validateParentRefs checks each parent's group matches its kind
+func validateParentRefs(spec map[string]interface{}) []FieldError {
+	var errs []FieldError
+	refs, _, _ := unstructured.NestedSlice(spec, "parentRefs")
+	for i, ref := range refs {
+		ref, ok := ref.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kind, _ := ref["kind"].(string)
+		group, _ := ref["group"].(string)
+		want, ok := parentGroups[kind]
+		if !ok {
+			continue
+		}
+		valid := false
+		for _, g := range want {
+			valid = valid || group == g
+		}
+		if !valid {
+			errs = append(errs, FieldError{
+				Field:   fmt.Sprintf("spec.parentRefs[%d].group", i),
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("spec.parentRefs[%d].group %q does not match kind %s (want %q)", i, group, kind, strings.Join(want, `" or "`)),
+			})
+		}
+	}
+	return errs
+}
+
+// validateRouteAnnotations checks the retry and timeout annotations Linkerd
+// reads from a route of kind k; no others are accepted
+func validateRouteAnnotations(k routeKind, annotations map[string]string) []FieldError {
+	var errs []FieldError
+	invalid := func(key, format string, args ...interface{}) {
+		errs = append(errs, FieldError{
+			Field:   fmt.Sprintf("metadata.annotations[%s]", key),
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("metadata.annotations[%s]: ", key) + fmt.Sprintf(format, args...),
+		})
+	}
+	for key, value := range annotations {
+		switch key {
+		case k.retryOn:
+			for _, item := range strings.Split(value, ",") {
+				item = strings.TrimSpace(item)
+				if k.retryOn == "retry.linkerd.io/http" && !httpStatusPattern.MatchString(item) {
+					invalid(key, "%q is not an HTTP status, range (500-504) or class (5xx)", item)
+				}
+				if k.retryOn == "retry.linkerd.io/grpc" && !grpcCodes[item] {
+					invalid(key, "%q is not a gRPC status code name (e.g. unavailable)", item)
+				}
+			}
+		case retryLimitAnnotation:
+			if n, err := strconv.Atoi(value); err != nil || n < 0 {
+				invalid(key, "%q is not a non-negative integer", value)
+			}
+		case retryTimeoutAnnotation, requestTimeoutAnnotation, responseTimeoutAnnotation, idleTimeoutAnnotation:
+			if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+				invalid(key, "%q is not a positive duration (e.g. 500ms)", value)
+			}
+		default:
+			errs = append(errs, FieldError{
+				Field:   fmt.Sprintf("metadata.annotations[%s]", key),
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("unsupported annotation %q; only Linkerd retry and timeout annotations are accepted", key),
+			})
+		}
+	}
+	return errs
+}
@@ -0,0 +1,84 @@
+// internal/policy/route_test.go
+
+package policy
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateRoute(t *testing.T) {
+	valid := specFromJSON(t, `{
+		"parentRefs": [{"group": "core", "kind": "Service", "name": "web", "port": 8080}],
+		"rules": [{
+			"matches": [{"path": {"type": "PathPrefix", "value": "/api"}, "headers": [{"name": "x-canary", "value": "true"}]}],
+			"backendRefs": [{"name": "web-v1", "port": 8080, "weight": 90}, {"name": "web-v2", "port": 8080, "weight": 10}],
+			"timeouts": {"request": "10s"}
+		}]
+	}`)
+	annotations := map[string]string{"retry.linkerd.io/http": "5xx,429", "retry.linkerd.io/limit": "2", "timeout.linkerd.io/request": "1500ms"}
+	if errs := ValidateRoute("HTTPRoute", "shop", "web", valid, annotations); len(errs) != 0 {
+		t.Errorf("expected a valid HTTPRoute, got %+v", errs)
+	}
+	grpc := specFromJSON(t, `{
+		"parentRefs": [{"group": "policy.linkerd.io", "kind": "Server", "name": "web-grpc"}],
+		"rules": [{"matches": [{"method": {"service": "shop.Cart", "method": "Checkout"}}], "backendRefs": [{"name": "cart", "port": 9090}]}]
+	}`)
+	if errs := ValidateRoute("GRPCRoute", "shop", "cart", grpc, map[string]string{"retry.linkerd.io/grpc": "unavailable"}); len(errs) != 0 {
+		t.Errorf("expected a valid GRPCRoute, got %+v", errs)
+	}
+
+	cases := []struct {
+		name        string
+		kind        string
+		spec        string
+		annotations map[string]string
+		want        []FieldError
+	}{
+		{
+			name: "missing parents",
+			kind: "HTTPRoute",
+			spec: `{"rules": []}`,
+			want: []FieldError{{Field: "spec.parentRefs", Type: metav1.CauseTypeFieldValueRequired}},
+		},
+		{
+			name: "unsupported parents and weights",
+			kind: "HTTPRoute",
+			spec: `{
+				"parentRefs": [{"kind": "Gateway", "name": "edge"}, {"kind": "Server", "name": "web-http"}],
+				"rules": [{"backendRefs": [{"name": "web", "weight": -1}], "timeouts": {"request": "soon"}}]
+			}`,
+			want: []FieldError{
+				{Field: "spec.parentRefs[0].kind", Type: metav1.CauseTypeFieldValueNotSupported},
+				{Field: "spec.parentRefs[1].group", Type: metav1.CauseTypeFieldValueNotSupported},
+				{Field: "spec.rules[0].backendRefs[0].weight", Type: metav1.CauseTypeFieldValueInvalid},
+				{Field: "spec.rules[0].timeouts.request", Type: metav1.CauseTypeFieldValueInvalid},
+			},
+		},
+		{
+			name:        "invalid annotations",
+			kind:        "GRPCRoute",
+			spec:        `{"parentRefs": [{"kind": "Service", "name": "cart"}]}`,
+			annotations: map[string]string{"retry.linkerd.io/grpc": "5xx", "retry.linkerd.io/limit": "-1", "example.com/owner": "me"},
+			want: []FieldError{
+				{Field: "metadata.annotations[example.com/owner]", Type: metav1.CauseTypeFieldValueNotSupported},
+				{Field: "metadata.annotations[retry.linkerd.io/grpc]", Type: metav1.CauseTypeFieldValueInvalid},
+				{Field: "metadata.annotations[retry.linkerd.io/limit]", Type: metav1.CauseTypeFieldValueInvalid},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateRoute(tc.kind, "shop", "web", specFromJSON(t, tc.spec), tc.annotations)
+			if len(errs) != len(tc.want) {
+				t.Fatalf("expected %d errors, got %+v", len(tc.want), errs)
+			}
+			for i, want := range tc.want {
+				if errs[i].Field != want.Field || errs[i].Type != want.Type || errs[i].Message == "" {
+					t.Errorf("error %d: expected %s %s, got %+v", i, want.Field, want.Type, errs[i])
+				}
+			}
+		})
+	}
+}
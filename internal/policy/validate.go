@@ -0,0 +1,193 @@
+// internal/policy/validate.go
+
+package policy
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/dynamic"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// authorizationPolicySchemaJSON is the spec schema of the Linkerd CRD, so
+// requests can be checked without a cluster
+//
+//go:embed schemas/authorizationpolicy.json
+var authorizationPolicySchemaJSON []byte
+
+var authorizationPolicySchema = mustSchema(authorizationPolicySchemaJSON)
+
+func mustSchema(data []byte) *spec.Schema {
+	var s spec.Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		panic(fmt.Sprintf("invalid embedded schema: %v", err))
+	}
+	return &s
+}
+
+// FieldError is a validation failure of one field, as in a Kubernetes
+// StatusCause
+type FieldError struct {
+	// Field is the path of the field, e.g. spec.targetRef.kind
+	Field   string
+	Type    metav1.CauseType
+	Message string
+}
+
+// Validator checks requested policies before they are accepted: offline
+// against the CRD schema and, given a cluster client, with a server-side
+// dry-run that runs the API server's own validation and admission.
+type Validator struct {
+	dyn dynamic.Interface
+}
+
+// NewValidator returns a Validator; a nil dyn skips the dry-run
+func NewValidator(dyn dynamic.Interface) *Validator {
+	return &Validator{dyn: dyn}
+}
+
+// AuthorizationPolicy validates an AuthorizationPolicy. It returns the field
+// errors when the policy is invalid, and an error when no verdict could be
+// reached (e.g. the API server is unreachable).
+func (v *Validator) AuthorizationPolicy(ctx context.Context, namespace, name string, spec map[string]interface{}) ([]FieldError, error) {
+	if errs := ValidateAuthorizationPolicy(namespace, name, spec); len(errs) > 0 {
+		return errs, nil
+	}
+	if v.dyn == nil {
+		return nil, nil
+	}
+	return DryRun(ctx, v.dyn, AuthorizationPolicyGVR, AuthorizationPolicy(namespace, name, spec))
+}
+
+// ValidateAuthorizationPolicy checks an AuthorizationPolicy's name and
+// namespace, and its spec against the CRD schema, including unknown fields
+func ValidateAuthorizationPolicy(namespace, name string, spec map[string]interface{}) []FieldError {
+	errs := validateMetadata(namespace, name)
+	var value interface{}
+	if spec != nil {
+		value = spec
+	}
+	return append(errs, validateSchema(authorizationPolicySchema, "spec", value)...)
+}
+
+func validateMetadata(namespace, name string) []FieldError {
+	var errs []FieldError
+	check := func(field, value string, validate func(string) []string) {
+		if value == "" {
+			errs = append(errs, FieldError{Field: field, Type: metav1.CauseTypeFieldValueRequired, Message: field + " is required"})
+			return
+		}
+		for _, msg := range validate(value) {
+			errs = append(errs, FieldError{Field: field, Type: metav1.CauseTypeFieldValueInvalid, Message: fmt.Sprintf("%s %q: %s", field, value, msg)})
+		}
+	}
+	check("metadata.namespace", namespace, validation.IsDNS1123Label)
+	check("metadata.name", name, validation.IsDNS1123Subdomain)
+	return errs
+}
+
+// validateSchema checks value, found at path root, against s
+func validateSchema(s *spec.Schema, root string, value interface{}) []FieldError {
+	var errs []FieldError
+	result := validate.NewSchemaValidator(s, nil, root, strfmt.Default).Validate(value)
+	for _, err := range result.Errors {
+		fe := FieldError{Field: root, Type: metav1.CauseTypeFieldValueInvalid, Message: err.Error()}
+		var v *openapierrors.Validation
+		if errors.As(err, &v) {
+			fe.Field = v.Name
+			fe.Type = causeType(v.Code())
+			fe.Message = strings.Replace(v.Error(), " in body", "", 1)
+		}
+		errs = append(errs, fe)
+	}
+	errs = append(errs, unknownFields(s, root, value)...)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+// unknownFields reports object members the schema does not declare, which
+// the API server would prune or, with strict validation, reject
+func unknownFields(s *spec.Schema, path string, value interface{}) []FieldError {
+	var errs []FieldError
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(s.Properties) == 0 || s.AdditionalProperties != nil {
+			return nil
+		}
+		for key, child := range v {
+			prop, ok := s.Properties[key]
+			if !ok {
+				errs = append(errs, FieldError{Field: path + "." + key, Type: metav1.CauseTypeFieldValueInvalid, Message: fmt.Sprintf("unknown field %q", path+"."+key)})
+				continue
+			}
+			errs = append(errs, unknownFields(&prop, path+"."+key, child)...)
+		}
+	case []interface{}:
+		if s.Items == nil || s.Items.Schema == nil {
+			return nil
+		}
+		for i, child := range v {
+			errs = append(errs, unknownFields(s.Items.Schema, fmt.Sprintf("%s[%d]", path, i), child)...)
+		}
+	}
+	return errs
+}
+
+// causeType maps a schema validation code to a Kubernetes cause type
+func causeType(code int32) metav1.CauseType {
+	switch code {
+	case openapierrors.RequiredFailCode:
+		return metav1.CauseTypeFieldValueRequired
+	case openapierrors.InvalidTypeCode:
+		return metav1.CauseTypeTypeInvalid
+	case openapierrors.EnumFailCode:
+		return metav1.CauseTypeFieldValueNotSupported
+	case openapierrors.TooLongFailCode:
+		return metav1.CauseTypeTooLong
+	case openapierrors.MaxItemsFailCode, openapierrors.TooManyPropertiesCode:
+		return metav1.CauseTypeTooMany
+	case openapierrors.UniqueFailCode:
+		return metav1.CauseTypeFieldValueDuplicate
+	default:
+		return metav1.CauseTypeFieldValueInvalid
+	}
+}
+
+// DryRun submits obj as a forced server-side apply with dryRun=All and strict
+// field validation, so nothing is persisted. A rejection of the content
+// returns its field errors; other failures return an error.
+func DryRun(ctx context.Context, dyn dynamic.Interface, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) ([]FieldError, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	force := true
+	_, err = dyn.Resource(gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		DryRun:          []string{metav1.DryRunAll},
+		FieldManager:    FieldManager,
+		Force:           &force,
+		FieldValidation: metav1.FieldValidationStrict,
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if apierrors.IsInvalid(err) || apierrors.IsBadRequest(err) {
+		return statusCauses(err), nil
+	}
+	return nil, fmt.Errorf("dry-run of %s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+}
@@ -0,0 +1,110 @@
+// internal/policy/validate_test.go
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func specFromJSON(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var spec map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &spec); err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+func TestValidateAuthorizationPolicy(t *testing.T) {
+	valid := specFromJSON(t, `{
+		"targetRef": {"group": "policy.linkerd.io", "kind": "Server", "name": "web-http"},
+		"requiredAuthenticationRefs": [{"group": "policy.linkerd.io", "kind": "MeshTLSAuthentication", "name": "web-clients"}]
+	}`)
+	if errs := ValidateAuthorizationPolicy("shop", "web", valid); len(errs) != 0 {
+		t.Errorf("expected a valid policy, got %+v", errs)
+	}
+
+	cases := []struct {
+		name      string
+		namespace string
+		spec      string
+		want      []FieldError
+	}{
+		{
+			name:      "missing fields",
+			namespace: "shop",
+			spec:      `{"targetRef": {"kind": "Server"}}`,
+			want: []FieldError{
+				{Field: "spec.requiredAuthenticationRefs", Type: metav1.CauseTypeFieldValueRequired},
+				{Field: "spec.targetRef.name", Type: metav1.CauseTypeFieldValueRequired},
+			},
+		},
+		{
+			name:      "invalid values and unknown fields",
+			namespace: "Shop",
+			spec: `{
+				"targetRef": {"kind": "Server!", "name": "web-http", "port": 80},
+				"requiredAuthenticationRefs": "all"
+			}`,
+			want: []FieldError{
+				{Field: "metadata.namespace", Type: metav1.CauseTypeFieldValueInvalid},
+				{Field: "spec.requiredAuthenticationRefs", Type: metav1.CauseTypeTypeInvalid},
+				{Field: "spec.targetRef.kind", Type: metav1.CauseTypeFieldValueInvalid},
+				{Field: "spec.targetRef.port", Type: metav1.CauseTypeFieldValueInvalid},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateAuthorizationPolicy(tc.namespace, "web", specFromJSON(t, tc.spec))
+			if len(errs) != len(tc.want) {
+				t.Fatalf("expected %d errors, got %+v", len(tc.want), errs)
+			}
+			for i, want := range tc.want {
+				if errs[i].Field != want.Field || errs[i].Type != want.Type || errs[i].Message == "" {
+					t.Errorf("error %d: expected %s %s, got %+v", i, want.Field, want.Type, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidator_DryRun(t *testing.T) {
+	dyn := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	var patched *k8stesting.PatchActionImpl
+	dyn.PrependReactor("patch", "authorizationpolicies", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		a := action.(k8stesting.PatchActionImpl)
+		patched = &a
+		return true, nil, apierrors.NewInvalid(schema.GroupKind{Group: "policy.linkerd.io", Kind: "AuthorizationPolicy"}, "web", field.ErrorList{
+			field.Invalid(field.NewPath("spec", "targetRef", "kind"), "Pod", "unsupported target kind"),
+		})
+	})
+
+	spec := specFromJSON(t, `{"targetRef": {"kind": "Pod", "name": "web-0"}, "requiredAuthenticationRefs": []}`)
+	errs, err := NewValidator(dyn).AuthorizationPolicy(context.Background(), "shop", "web", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Field != "spec.targetRef.kind" || errs[0].Type != metav1.CauseTypeFieldValueInvalid {
+		t.Errorf("expected the API server's field error, got %+v", errs)
+	}
+	if patched == nil || patched.GetPatchType() != "application/apply-patch+yaml" || patched.GetNamespace() != "shop" || patched.GetName() != "web" {
+		t.Errorf("expected a server-side apply of shop/web, got %+v", patched)
+	}
+
+	// Offline failures never reach the API server
+	patched = nil
+	if errs, _ := NewValidator(dyn).AuthorizationPolicy(context.Background(), "shop", "web", nil); len(errs) == 0 || patched != nil {
+		t.Errorf("expected an offline rejection without a dry-run, got %+v", errs)
+	}
+}
@@ -0,0 +1,59 @@
+// internal/redis/lease.go
+
+package redisutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/eli-nomasec/linkerd2-mcp/internal/leader"
+	"github.com/redis/go-redis/v9"
+)
+
+const leaderKey = "mesh:leader"
+
+// acquireScript takes the lease when it is free, or refreshes it when the
+// holder already owns it (e.g. after a container restart in the same pod)
+var acquireScript = redis.NewScript(`
+local holder = redis.call('GET', KEYS[1])
+if holder == false then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+  return 1
+end
+if holder == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  return 1
+end
+return 0
+`)
+
+// renewScript extends the lease only if ARGV[1] still holds it
+var renewScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript deletes the lease only if ARGV[1] still holds it
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// RenewLeader extends the lease only while holder still owns it
+func (r *RedisClient) RenewLeader(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	return renewScript.Run(ctx, r.Client, []string{leaderKey}, holder, ttl.Milliseconds()).Bool()
+}
+
+// ReleaseLeader deletes the lease only if holder still owns it
+func (r *RedisClient) ReleaseLeader(ctx context.Context, holder string) error {
+	return releaseScript.Run(ctx, r.Client, []string{leaderKey}, holder).Err()
+}
+
+// NewElector elects a leader through the mesh:leader key
+func (r *RedisClient) NewElector(id string, ttl time.Duration, callbacks leader.Callbacks) leader.Elector {
+	return leader.NewLease(r, leaderKey, id, ttl, callbacks)
+}
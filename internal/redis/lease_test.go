@@ -0,0 +1,60 @@
+// internal/redis/lease_test.go
+
+package redisutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestTryAcquireLeader(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r := NewRedisClient(mr.Addr())
+	ctx := context.Background()
+
+	if ok, err := r.TryAcquireLeader(ctx, "pod-a", time.Minute); err != nil || !ok {
+		t.Fatalf("expected pod-a to acquire, ok=%t err=%v", ok, err)
+	}
+	if ok, _ := r.TryAcquireLeader(ctx, "pod-b", time.Minute); ok {
+		t.Errorf("expected pod-b to be refused while pod-a holds the lease")
+	}
+	// Re-acquiring as the current holder succeeds
+	if ok, _ := r.TryAcquireLeader(ctx, "pod-a", time.Minute); !ok {
+		t.Errorf("expected pod-a to re-acquire its own lease")
+	}
+}
+
+func TestRenewAndReleaseLeader(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r := NewRedisClient(mr.Addr())
+	ctx := context.Background()
+
+	if ok, _ := r.TryAcquireLeader(ctx, "pod-a", time.Minute); !ok {
+		t.Fatalf("expected pod-a to acquire")
+	}
+	// Someone else takes the key: renewing is a compare-and-set on the holder
+	mr.Set(leaderKey, "pod-b")
+	if ok, err := r.RenewLeader(ctx, "pod-a", time.Minute); err != nil || ok {
+		t.Errorf("expected pod-a's renew to fail, ok=%t err=%v", ok, err)
+	}
+	if ok, _ := r.RenewLeader(ctx, "pod-b", time.Minute); !ok {
+		t.Errorf("expected pod-b to renew its lease")
+	}
+
+	// Releasing only deletes the key for its holder
+	if err := r.ReleaseLeader(ctx, "pod-a"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := mr.Get(leaderKey); v != "pod-b" {
+		t.Errorf("pod-a must not release pod-b's lease, key=%q", v)
+	}
+	if err := r.ReleaseLeader(ctx, "pod-b"); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(leaderKey) {
+		t.Errorf("expected pod-b to release the lease")
+	}
+}
@@ -25,20 +25,68 @@ func NewRedisClient(addr string) *RedisClient {
 	}
 }
 
-// SetMeshSnapshot sets the mesh snapshot with expiration (ttl)
-func (r *RedisClient) SetMeshSnapshot(ctx context.Context, data []byte, ttl time.Duration) error {
-	return r.Client.Set(ctx, "mesh:snapshot", data, ttl).Err()
+// NewRedisClientURL connects using a redis:// or rediss:// URL, which may
+// carry credentials and a database number
+func NewRedisClientURL(url string) (*RedisClient, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisClient{
+		Addr:   opts.Addr,
+		Client: redis.NewClient(opts),
+	}, nil
+}
+
+// Ping checks that Redis answers
+func (r *RedisClient) Ping(ctx context.Context) error {
+	return r.Client.Ping(ctx).Err()
+}
+
+// Close closes the connection pool
+func (r *RedisClient) Close() error {
+	return r.Client.Close()
+}
+
+// sequenceScript takes the next mesh:seq, splices it into the JSON objects in
+// ARGV as a leading "seq" member and publishes the delta (ARGV[1]), storing
+// the snapshot (ARGV[2]) first when given, so sequence order is publish order.
+var sequenceScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+local function stamp(payload)
+  return '{"seq":' .. seq .. ',' .. string.sub(payload, 2)
+end
+if ARGV[2] then
+  redis.call('SET', KEYS[2], stamp(ARGV[2]), 'PX', ARGV[3])
+end
+redis.call('PUBLISH', KEYS[3], stamp(ARGV[1]))
+return seq
+`)
+
+// PublishMeshDelta publishes a mesh delta to the mesh:delta channel and returns
+// the sequence number it was assigned. delta must be a non-empty JSON object
+// without a "seq" member.
+func (r *RedisClient) PublishMeshDelta(ctx context.Context, delta []byte) (uint64, error) {
+	return sequenceScript.Run(ctx, r.Client, []string{"mesh:seq", "mesh:snapshot", "mesh:delta"}, delta).Uint64()
+}
+
+// PublishMeshUpdate atomically stores snapshot and publishes delta under the
+// same sequence number, which it returns. Both must be non-empty JSON objects
+// without a "seq" member.
+func (r *RedisClient) PublishMeshUpdate(ctx context.Context, snapshot, delta []byte, ttl time.Duration) (uint64, error) {
+	return sequenceScript.Run(ctx, r.Client, []string{"mesh:seq", "mesh:snapshot", "mesh:delta"}, delta, snapshot, ttl.Milliseconds()).Uint64()
 }
 
-// PublishMeshDelta publishes a mesh delta to the mesh:delta channel
-func (r *RedisClient) PublishMeshDelta(ctx context.Context, delta []byte) error {
-	return r.Client.Publish(ctx, "mesh:delta", delta).Err()
+// RefreshMeshSnapshot extends the snapshot's expiration without rewriting it
+func (r *RedisClient) RefreshMeshSnapshot(ctx context.Context, ttl time.Duration) error {
+	return r.Client.Expire(ctx, "mesh:snapshot", ttl).Err()
 }
 
-// TryAcquireLeader attempts to acquire leadership using SETNX with expiration
+// TryAcquireLeader attempts to acquire leadership of mesh:leader with
+// expiration. It also succeeds, refreshing the expiration, when podUID
+// already holds it.
 func (r *RedisClient) TryAcquireLeader(ctx context.Context, podUID string, ttl time.Duration) (bool, error) {
-	ok, err := r.Client.SetNX(ctx, "mesh:leader", podUID, ttl).Result()
-	return ok, err
+	return acquireScript.Run(ctx, r.Client, []string{leaderKey}, podUID, ttl.Milliseconds()).Bool()
 }
 
 // GetMeshSnapshot retrieves the mesh snapshot from Redis
@@ -27,7 +27,7 @@ grpcurl -plaintext localhost:10900 mcp.v1.MeshContext/GetMeshGraph
 
 # Test ApplyAuthorizationPolicy
 echo "Testing ApplyAuthorizationPolicy..."
-grpcurl -plaintext -d '{"namespace":"default","name":"allow-foo","json_spec":"{\"foo\": \"bar\"}"}' localhost:10900 mcp.v1.MeshContext/ApplyAuthorizationPolicy
+grpcurl -plaintext -d '{"namespace":"default","name":"allow-foo","json_spec":"{\"targetRef\":{\"group\":\"policy.linkerd.io\",\"kind\":\"Server\",\"name\":\"foo\"},\"requiredAuthenticationRefs\":[]}"}' localhost:10900 mcp.v1.MeshContext/ApplyAuthorizationPolicy
 
 # Verify AuthorizationPolicy CR
 echo "Verifying AuthorizationPolicy CRs in the cluster..."
@@ -4,7 +4,6 @@ package integration
 
 import (
 	"context"
-	"encoding/json"
 	"os"
 	"testing"
 	"time"
@@ -45,41 +44,35 @@ func TestMeshGraphIncludesDemoApp(t *testing.T) {
 		t.Fatalf("GetMeshGraph failed: %v", err)
 	}
 
-	// The mesh graph is returned as JSON
-	type Service struct {
-		Name string `json:"name"`
-	}
-	type Edge struct {
-		Src string `json:"src"`
-		Dst string `json:"dst"`
-	}
-	type MeshGraph struct {
-		Services map[string]Service `json:"services"`
-		Edges    []Edge             `json:"edges"`
-	}
-
-	var graph MeshGraph
-	jsonStr := resp.GetJsonGraph()
-	if err := json.Unmarshal([]byte(jsonStr), &graph); err != nil {
-		t.Fatalf("failed to unmarshal mesh graph JSON: %v", err)
+	graph := resp.GetGraph()
+	if graph == nil {
+		t.Fatalf("GetMeshGraph returned no graph")
 	}
 
 	// Check for service-a and service-b
-	_, foundA := graph.Services["service-a"]
-	_, foundB := graph.Services["service-b"]
+	// Services are keyed by namespace/name; the demo namespace depends on the deployment
+	var foundA, foundB bool
+	for _, svc := range graph.GetServices() {
+		switch svc.GetName() {
+		case "service-a":
+			foundA = true
+		case "service-b":
+			foundB = true
+		}
+	}
 	if !foundA || !foundB {
-		t.Fatalf("expected both service-a and service-b in mesh, got: %+v", graph.Services)
+		t.Fatalf("expected both service-a and service-b in mesh, got: %+v", graph.GetServices())
 	}
 
 	// Check for an edge from service-a to service-b
 	var foundEdge bool
-	for _, edge := range graph.Edges {
-		if edge.Src == "service-a" && edge.Dst == "service-b" {
+	for _, edge := range graph.GetEdges() {
+		if edge.GetSrc() == "service-a" && edge.GetDst() == "service-b" {
 			foundEdge = true
 			break
 		}
 	}
 	if !foundEdge {
-		t.Fatalf("expected edge from service-a to service-b, got: %+v", graph.Edges)
+		t.Fatalf("expected edge from service-a to service-b, got: %+v", graph.GetEdges())
 	}
 }